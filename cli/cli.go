@@ -1,37 +1,77 @@
 package cli
 
-import "github.com/phuslu/log"
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/phuslu/log"
+)
 
 type StartDay func(*App) Day
 
+const (
+	FormatView = "view"
+	FormatJSON = "json"
+)
+
 type AppConfig struct {
+	Format    string
+	Watch     bool
+	GraphFile string
 }
 
 type Day interface {
 	Run()
 }
 
+// Solver runs a day, reporting its progress as events to the callback.
+type Solver func(ctx context.Context, callback func(ctx context.Context, obj any))
+
+// ChannelSolver adapts days that send their events on a channel.
+func ChannelSolver(run func(ctx context.Context, listener chan<- utils.Event) error) Solver {
+	return func(ctx context.Context, callback func(ctx context.Context, obj any)) {
+		events := make(chan utils.Event)
+		go func() {
+			defer close(events)
+			if err := run(ctx, events); err != nil {
+				log.Error().Err(err).Msg("day stopped")
+			}
+		}()
+		for e := range events {
+			callback(ctx, e)
+		}
+	}
+}
+
 type App struct {
 	Config AppConfig
 	Day    Day
 
-	daysRegistry map[int]Day
+	daysRegistry    map[int]Day
+	solversRegistry map[int]Solver
 }
 
 func NewApp(c AppConfig) *App {
+	if c.Format == "" {
+		c.Format = FormatView
+	}
 	return &App{
-		Config:       c,
-		Day:          nil,
-		daysRegistry: make(map[int]Day),
+		Config:          c,
+		Day:             nil,
+		daysRegistry:    make(map[int]Day),
+		solversRegistry: make(map[int]Solver),
 	}
 }
 
-func (a *App) RegisterDay(day int, dayApp Day) {
+func (a *App) RegisterDay(day int, dayApp Day, solver Solver) {
 	log.Info().Int("day", day).Msg("Registering day")
 	if _, ok := a.daysRegistry[day]; ok {
 		log.Fatal().Msg("Already registered")
 	}
 	a.daysRegistry[day] = dayApp
+	a.solversRegistry[day] = solver
 }
 
 func (a App) isRegistered(day int) bool {
@@ -45,5 +85,34 @@ func (a App) Run(day int) {
 		log.Fatal().Int("day", day).Msg("No such day for cli")
 	}
 
-	app.Run()
+	if a.Config.Watch {
+		a.Watch(day)
+		return
+	}
+
+	if a.Config.GraphFile != "" {
+		a.exportGraph(day)
+		return
+	}
+
+	switch a.Config.Format {
+	case FormatView:
+		app.Run()
+	case FormatJSON:
+		a.runJSON(day)
+	default:
+		log.Fatal().Str("format", a.Config.Format).Msg("Unknown output format")
+	}
+}
+
+func (a App) runJSON(day int) {
+	// Some days print their grids: keep stdout for the report only.
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	report := runReport(context.Background(), day, a.solversRegistry[day])
+	os.Stdout = stdout
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	utils.MustSucceed(encoder.Encode(report))
 }
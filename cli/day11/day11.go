@@ -5,6 +5,7 @@ import (
 
 	"github.com/gverger/aoc2024/cli"
 	"github.com/gverger/aoc2024/day11"
+	"github.com/gverger/aoc2024/utils"
 	"github.com/phuslu/log"
 )
 
@@ -28,6 +29,9 @@ func (a *App) callback(ctx context.Context, event any) {
 		log.Info().Interface("event", e).Msg("loaded")
 	case day11.SolutionFound:
 		log.Info().Interface("event", e).Msg("solution")
+	case utils.MemoStatsUpdated:
+		log.Info().Str("cache", e.Name).Int("hits", e.Stats.Hits).Int("misses", e.Stats.Misses).
+			Int("size", e.Stats.Size).Float64("hit_rate", e.Stats.HitRate()).Msg("cache stats")
 	}
 }
 
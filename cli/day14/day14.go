@@ -57,22 +57,14 @@ func (m *model) callback(ctx context.Context, event any) {
 	switch e := event.(type) {
 	case day14.InputLoaded:
 		log.Info().Interface("event", e).Msg("loaded")
-		g := utils.NewGrid[int](uint(e.Width), uint(e.Height))
-		for _, r := range e.Input.Robots {
-			x := utils.Mod(r.Position.X, e.Width)
-			y := utils.Mod(r.Position.Y, e.Height)
-			g.Set(x, y, g.At(x, y)+1)
-		}
+		g := utils.CountWrapped(uint(e.Width), uint(e.Height), e.Input.Robots, func(r day14.Robot) (int, int) {
+			return r.Position.XY()
+		})
 		m.changes <- Change{Turn: 0, Grid: *g}
 	case day14.SolutionFound:
 		log.Info().Interface("event", e).Msg("solution")
 	case day14.StateUpdated:
-		g := utils.NewGrid[int](uint(e.Width), uint(e.Height))
-		for _, p := range e.Positions {
-			x := utils.Mod(p.X, e.Width)
-			y := utils.Mod(p.Y, e.Height)
-			g.Set(x, y, g.At(x, y)+1)
-		}
+		g := utils.CountWrapped(uint(e.Width), uint(e.Height), e.Positions, utils.Point.XY)
 		m.changes <- Change{Turn: e.Turn, Grid: *g}
 
 		for r := range g.AllCells() {
@@ -44,6 +44,8 @@ type model struct {
 	grid utils.Grid[day16.CellType]
 	sol1 int
 	sol2 int
+	// settled counts the nodes settled by the searches.
+	settled int
 
 	changes chan Change
 	done    chan Done
@@ -61,6 +63,10 @@ func (m *model) callback(ctx context.Context, event any) {
 	case day16.SolutionFound:
 		log.Info().Interface("event", e).Msg("solution")
 		m.changes <- Change{Event: e}
+	case utils.SearchEvent[day16.Reindeer, int]:
+		if e.Kind == utils.SearchSettle {
+			m.changes <- Change{Event: e}
+		}
 	}
 }
 
@@ -86,6 +92,8 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case Change:
 		switch e := msg.Event.(type) {
+		case utils.SearchEvent[day16.Reindeer, int]:
+			m.settled++
 		case day16.InputLoaded:
 			m.grid = *e.Input.Grid
 		case day16.SolutionFound:
@@ -112,6 +120,7 @@ func (m model) View() string {
 
 	sb.WriteString(fmt.Sprintf("Solution 1: %v\n", m.sol1))
 	sb.WriteString(fmt.Sprintf("Solution 2: %v\n", m.sol2))
+	sb.WriteString(fmt.Sprintf("Settled: %d\n", m.settled))
 	sb.WriteString(fmt.Sprintf("Grid:\n%v\n", m.grid.Stringf(func(ct day16.CellType) string {
 		switch ct {
 		case day16.Empty:
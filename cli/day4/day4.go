@@ -43,6 +43,7 @@ func (a *App) Run() {
 		for !a.state.IsDone {
 			event := <-events
 			switch e := event.(type) {
+			case utils.InputRead:
 			case day4.InputLoaded:
 				a.state.Input = e.Input
 				log.Info().Msg("Input loaded")
@@ -71,16 +71,7 @@ func display(g *Grid[day6.CellType], guard *day6.Guard) {
 		fmt.Print("|")
 		for x := 0; x < int(g.Width); x++ {
 			if guard.X == x && guard.Y == y {
-				switch guard.Dir {
-				case DirUp:
-					fmt.Print("^")
-				case DirDown:
-					fmt.Print("v")
-				case DirRight:
-					fmt.Print(">")
-				case DirLeft:
-					fmt.Print("<")
-				}
+				fmt.Print(guard.Dir)
 			} else {
 				switch g.At(x, y) {
 				case day6.EmptyCell:
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/phuslu/log"
+)
+
+// exportGraph solves the day and writes the last graph it built, in Mermaid for
+// .mmd and .mermaid files, in DOT otherwise.
+func (a App) exportGraph(day int) {
+	var built *utils.GraphBuilt
+	a.solversRegistry[day](context.Background(), func(ctx context.Context, event any) {
+		if g, ok := event.(utils.GraphBuilt); ok {
+			built = &g
+		}
+	})
+	if built == nil {
+		log.Fatal().Int("day", day).Msg("No graph built by this day")
+	}
+
+	var content string
+	switch filepath.Ext(a.Config.GraphFile) {
+	case ".mmd", ".mermaid":
+		content = built.Build().Mermaid()
+	default:
+		content = built.Build().DOT()
+	}
+	utils.MustSucceed(os.WriteFile(a.Config.GraphFile, []byte(content), 0o644))
+	log.Info().Str("graph", built.Name).Str("file", a.Config.GraphFile).Msg("Graph written")
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/gverger/aoc2024/utils"
+)
+
+const (
+	StatusOK      = "ok"
+	StatusPartial = "partial"
+	StatusFailed  = "failed"
+)
+
+// Report is the machine readable outcome of a day run, printed with --format json.
+type Report struct {
+	Day        int      `json:"day"`
+	Status     string   `json:"status"`
+	Answers    []Answer `json:"answers"`
+	InputFile  string   `json:"input_file,omitempty"`
+	InputHash  string   `json:"input_hash,omitempty"`
+	DurationMs float64  `json:"duration_ms"`
+	Error      string   `json:"error,omitempty"`
+}
+
+type Answer struct {
+	Part       int     `json:"part"`
+	Type       string  `json:"type"`
+	Value      any     `json:"value"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+func runReport(ctx context.Context, day int, solve Solver) Report {
+	r := Report{Day: day, Answers: make([]Answer, 0)}
+
+	start := time.Now()
+	func() {
+		defer func() {
+			if err := recover(); err != nil {
+				r.Error = fmt.Sprint(err)
+			}
+		}()
+
+		solve(ctx, func(ctx context.Context, event any) {
+			if input, ok := event.(utils.InputRead); ok {
+				sum := sha256.Sum256(input.Data)
+				r.InputFile = input.File
+				r.InputHash = hex.EncodeToString(sum[:])
+			}
+			part, ok := eventField(event, "SolutionFound", "Part")
+			if !ok {
+				return
+			}
+			solution, ok := eventField(event, "SolutionFound", "Solution")
+			if !ok {
+				return
+			}
+			r.Answers = append(r.Answers, Answer{
+				Part:       int(part.Int()),
+				Type:       solution.Type().String(),
+				Value:      solution.Interface(),
+				DurationMs: milliseconds(time.Since(start)),
+			})
+		})
+	}()
+	r.DurationMs = milliseconds(time.Since(start))
+
+	parts := make(map[int]bool)
+	for _, a := range r.Answers {
+		parts[a.Part] = true
+	}
+	switch {
+	case r.Error != "" || len(parts) == 0:
+		r.Status = StatusFailed
+	case parts[1] && parts[2]:
+		r.Status = StatusOK
+	default:
+		r.Status = StatusPartial
+	}
+
+	return r
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// eventField returns the field of an event if the event is a struct named
+// typeName. Each day declares its own InputLoaded and SolutionFound types, so
+// they can only be recognised by their shape.
+func eventField(event any, typeName string, field string) (reflect.Value, bool) {
+	v := reflect.ValueOf(event)
+	if v.Kind() != reflect.Struct || v.Type().Name() != typeName {
+		return reflect.Value{}, false
+	}
+	f := v.FieldByName(field)
+	return f, f.IsValid()
+}
@@ -0,0 +1,283 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gverger/aoc2024/utils"
+	"github.com/phuslu/log"
+)
+
+const watchInterval = 500 * time.Millisecond
+
+// Watch re-runs a day each time a file of its packages changes. Days embed
+// their inputs, so the day is rebuilt and run from the module root as a child
+// process reporting in json, and its answers are compared with the last run.
+func (a App) Watch(day int) {
+	if !a.isRegistered(day) {
+		log.Fatal().Int("day", day).Msg("No such day for cli")
+	}
+
+	root := moduleRoot()
+	dirs := watchedDirs(root, day)
+	log.Info().Str("root", root).Strs("dirs", dirs).Msg("Watching")
+
+	m := &watchModel{
+		day:     day,
+		dirs:    dirs,
+		changes: make(chan runDone),
+		styles: watchStyles{
+			title:   lipgloss.NewStyle().Bold(true),
+			changed: lipgloss.NewStyle().Foreground(lipgloss.Color("#ffaa00")).Bold(true),
+			same:    lipgloss.NewStyle().Foreground(lipgloss.Color("#009944")),
+			failed:  lipgloss.NewStyle().Foreground(lipgloss.Color("#990044")),
+			faint:   lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")),
+		},
+	}
+
+	go watch(dirs, func() {
+		m.changes <- runDone{Running: true}
+		m.changes <- runDay(root, day)
+	})
+
+	utils.Must(tea.NewProgram(m).Run())
+}
+
+// moduleRoot finds the go.mod of the cli, above the binary when it was built in
+// the repository, or from the go tool otherwise, as go run builds elsewhere.
+func moduleRoot() string {
+	if binary, err := os.Executable(); err == nil {
+		for dir := filepath.Dir(binary); ; dir = filepath.Dir(dir) {
+			if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+				return dir
+			}
+			if dir == filepath.Dir(dir) {
+				break
+			}
+		}
+	}
+
+	out, err := exec.Command("go", "env", "GOMOD").Output()
+	gomod := strings.TrimSpace(string(out))
+	if err != nil || gomod == "" || gomod == os.DevNull {
+		log.Fatal().Err(err).Msg("Cannot find the module root, run from the repository")
+	}
+	return filepath.Dir(gomod)
+}
+
+// watchedDirs returns the solver and viewer packages of a day. The inputs live
+// next to the solver, so they are watched too.
+func watchedDirs(root string, day int) []string {
+	name := fmt.Sprintf("day%d", day)
+	dirs := make([]string, 0, 2)
+	for _, dir := range []string{name, filepath.Join("cli", name)} {
+		dir = filepath.Join(root, dir)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// watch calls onChange once, then each time a file in dirs is modified.
+func watch(dirs []string, onChange func()) {
+	last := snapshot(dirs)
+	onChange()
+
+	for range time.Tick(watchInterval) {
+		current := snapshot(dirs)
+		if current != last {
+			last = current
+			onChange()
+		}
+	}
+}
+
+func snapshot(dirs []string) string {
+	var sb strings.Builder
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			sb.WriteString(fmt.Sprintf("%s:%d:%d\n", path, info.Size(), info.ModTime().UnixNano()))
+			return nil
+		})
+		if err != nil {
+			log.Warn().Err(err).Str("dir", dir).Msg("cannot walk directory")
+		}
+	}
+	return sb.String()
+}
+
+type runDone struct {
+	Running bool
+	Report  Report
+	Err     error
+	Stderr  string
+}
+
+// runDay runs the day with the go tool, so that both build and run failures
+// end up in stderr.
+func runDay(root string, day int) runDone {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", "run", ".", "--cli", strconv.Itoa(day), "--format", FormatJSON)
+	cmd.Dir = root
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return runDone{Err: err, Stderr: stderr.String()}
+	}
+
+	var r Report
+	decoder := json.NewDecoder(&stdout)
+	decoder.UseNumber() // keep int64 answers exact
+	if err := decoder.Decode(&r); err != nil {
+		return runDone{Err: err, Stderr: stderr.String()}
+	}
+	return runDone{Report: r}
+}
+
+type watchStyles struct {
+	title   lipgloss.Style
+	changed lipgloss.Style
+	same    lipgloss.Style
+	failed  lipgloss.Style
+	faint   lipgloss.Style
+}
+
+type watchModel struct {
+	day     int
+	dirs    []string
+	runs    int
+	running bool
+	last    runDone
+
+	// previous holds the answers of the last successful run, kept across
+	// failed ones so that a fix is compared with what worked before.
+	previous map[int]Answer
+
+	changes chan runDone
+	styles  watchStyles
+}
+
+func waitForRun(changes chan runDone) tea.Cmd {
+	return func() tea.Msg {
+		return <-changes
+	}
+}
+
+// Init implements tea.Model.
+func (m *watchModel) Init() tea.Cmd {
+	return waitForRun(m.changes)
+}
+
+// Update implements tea.Model.
+func (m *watchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case runDone:
+		if msg.Running {
+			m.running = true
+			return m, waitForRun(m.changes)
+		}
+		if m.runs > 0 && m.last.Err == nil {
+			m.previous = answersByPart(m.last.Report)
+		}
+		m.running = false
+		m.runs++
+		m.last = msg
+		return m, waitForRun(m.changes)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+func answersByPart(r Report) map[int]Answer {
+	answers := make(map[int]Answer)
+	for _, a := range r.Answers {
+		answers[a.Part] = a
+	}
+	return answers
+}
+
+// View implements tea.Model.
+func (m watchModel) View() string {
+	var sb strings.Builder
+
+	state := fmt.Sprintf("run #%d", m.runs)
+	if m.running {
+		state = "running..."
+	}
+	sb.WriteString(m.styles.title.Render(fmt.Sprintf("Day %d", m.day)))
+	sb.WriteString(m.styles.faint.Render(fmt.Sprintf(" watching, %s (q to quit)", state)))
+	sb.WriteString("\n")
+	for _, dir := range m.dirs {
+		sb.WriteString(m.styles.faint.Render("  " + dir))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	if m.runs == 0 {
+		return sb.String()
+	}
+
+	if m.last.Err != nil {
+		sb.WriteString(m.styles.failed.Render(fmt.Sprintf("Run failed: %v", m.last.Err)))
+		sb.WriteString("\n")
+		sb.WriteString(m.styles.failed.Render(lastLines(m.last.Stderr, 10)))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	r := m.last.Report
+	sb.WriteString(fmt.Sprintf("Status: %s, %.3fms\n", r.Status, r.DurationMs))
+	for _, a := range r.Answers {
+		line := fmt.Sprintf("Solution %d: %v", a.Part, a.Value)
+		prev, ok := m.previous[a.Part]
+		switch {
+		case m.previous == nil:
+			sb.WriteString(line)
+		case !ok:
+			sb.WriteString(m.styles.changed.Render(line + " (new)"))
+		case fmt.Sprint(prev.Value) != fmt.Sprint(a.Value):
+			sb.WriteString(m.styles.changed.Render(fmt.Sprintf("%s (was %v)", line, prev.Value)))
+		default:
+			sb.WriteString(m.styles.same.Render(line))
+		}
+		sb.WriteString(m.styles.faint.Render(fmt.Sprintf("  %.3fms", a.DurationMs)))
+		sb.WriteString("\n")
+	}
+	if r.Error != "" {
+		sb.WriteString(m.styles.failed.Render("Error: " + r.Error))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func lastLines(text string, n int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	return strings.Join(lines[max(0, len(lines)-n):], "\n")
+}
+
+var _ tea.Model = &watchModel{}
@@ -105,7 +105,9 @@ type SolutionFound struct {
 
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 
-	input := ReadInput("input.txt")
+	filename := "input.txt"
+	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 	callback(ctx, InputLoaded{Input: input})
 	accessible := access(input.Grid)
 
@@ -55,55 +55,52 @@ func digits(n int) int {
 	return int(math.Floor(math.Log10(float64(n)) + 1))
 }
 
-func countStones(stone int, depth int, cache map[ComputedResult]int) int {
-	log.Debug().Int("stone", stone).Int("depth", depth).Msg("counting")
-	input := ComputedResult{stone: stone, depth: depth}
-	if result, ok := cache[input]; ok {
-		return result
-	}
-	if depth == 0 {
-		return 1
-	}
-
-	if stone == 0 {
-		result := countStones(1, depth-1, cache)
-		cache[input] = result
-		return result
-	}
-
-	nbDigits := digits(stone)
-	if nbDigits%2 != 0 {
-		result := countStones(stone*2024, depth-1, cache)
-		cache[input] = result
-		return result
-	}
-
-	divider := int(math.Pow10(nbDigits / 2))
-	log.Debug().Int("div", divider).Int("first", stone/divider).Int("second", stone%divider).Msg("divide")
-	result1 := countStones(stone%divider, depth-1, cache)
-	result2 := countStones(stone/divider, depth-1, cache)
-	cache[input] = result1 + result2
-
-	return result1 + result2
+// newStoneCounter counts the stones a stone turns into after depth blinks.
+func newStoneCounter() *Memo[ComputedResult, int] {
+	return NewMemo(func(count func(ComputedResult) int, input ComputedResult) int {
+		stone, depth := input.stone, input.depth
+		log.Debug().Int("stone", stone).Int("depth", depth).Msg("counting")
+		if depth == 0 {
+			return 1
+		}
+
+		if stone == 0 {
+			return count(ComputedResult{stone: 1, depth: depth - 1})
+		}
+
+		nbDigits := digits(stone)
+		if nbDigits%2 != 0 {
+			return count(ComputedResult{stone: stone * 2024, depth: depth - 1})
+		}
+
+		divider := int(math.Pow10(nbDigits / 2))
+		log.Debug().Int("div", divider).Int("first", stone/divider).Int("second", stone%divider).Msg("divide")
+		return count(ComputedResult{stone: stone % divider, depth: depth - 1}) +
+			count(ComputedResult{stone: stone / divider, depth: depth - 1})
+	})
 }
 
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 	log.DefaultLogger.SetLevel(log.InfoLevel)
 
-	input := ReadInput("input.txt")
+	filename := "input.txt"
+	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 	callback(ctx, InputLoaded{Input: input})
 
-	cache := make(map[ComputedResult]int)
+	counter := newStoneCounter()
 
 	sum := 0
 	for _, n := range input.Numbers {
-		sum += countStones(n, 25, cache)
+		sum += counter.Get(ComputedResult{stone: n, depth: 25})
 	}
+	callback(ctx, MemoStatsUpdated{Name: "stones", Stats: counter.Stats()})
 	callback(ctx, SolutionFound{Part: 1, Solution: sum})
 
 	sum = 0
 	for _, n := range input.Numbers {
-		sum += countStones(n, 75, cache)
+		sum += counter.Get(ComputedResult{stone: n, depth: 75})
 	}
+	callback(ctx, MemoStatsUpdated{Name: "stones", Stats: counter.Stats()})
 	callback(ctx, SolutionFound{Part: 2, Solution: sum})
 }
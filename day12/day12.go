@@ -49,111 +49,24 @@ type SolutionFound struct {
 	Solution int
 }
 
-type Pos struct {
-	X int
-	Y int
+func samePlant(a, b rune) bool {
+	return a == b
 }
 
-func Region(farm Grid[rune], visited *Grid[bool], x int, y int) []Pos {
-	positions := make([]Pos, 0)
-	positions = append(positions, Pos{X: x, Y: y})
-	visited.Set(x, y, true)
-
-	idx := 0
-
-	n := NewNeighbors4[rune]()
-	region := farm.At(x, y)
-
-	for idx < len(positions) {
-		current := positions[idx]
-		neighbors := n.NeighborCells(farm, current.X, current.Y)
-
-		for _, c := range neighbors {
-			if c.Value == region && !visited.At(c.X, c.Y) {
-				positions = append(positions, Pos{c.X, c.Y})
-				visited.Set(c.X, c.Y, true)
-			}
-		}
-
-		idx++
-	}
-
-	return positions
+func RegionPrice(r Region[rune]) int {
+	return r.Geometry().Perimeter * r.Area()
 }
 
-func RegionPrice(farm Grid[rune], visited *Grid[bool], x int, y int) int {
-	positions := Region(farm, visited, x, y)
-
-	n := NewNeighbors4[rune]()
-	fences := 0
-	region := farm.At(x, y)
-
-	for _, current := range positions {
-		neighbors := n.NeighborCells(farm, current.X, current.Y)
-		fences += 4 - len(neighbors)
-
-		for _, c := range neighbors {
-			if c.Value != region {
-				fences++
-			}
-		}
-	}
-
-	return fences * len(positions)
-}
-
-func RegionPriceWithDiscount(farm Grid[rune], visited *Grid[bool], x int, y int) int {
-	positions := Region(farm, visited, x, y)
-
-	cornerPlots := NewGrid[string](farm.Width, farm.Height)
-	cornerPlots.SetAll(" ")
-
-	horizontal := []Direction{DirRight, DirLeft}
-	vertical := []Direction{DirUp, DirDown}
-
-	corners := 0
-	region := farm.At(x, y)
-
-	for _, current := range positions {
-		cornerPlots.Set(current.X, current.Y, ".")
-
-		// External corners
-		for _, h := range horizontal {
-			hx, hy := h.Apply(current.X, current.Y)
-
-			if farm.IsCoordValid(hx, hy) && farm.At(hx, hy) == region {
-				for _, v := range vertical {
-					vx, vy := v.Apply(current.X, current.Y)
-					if farm.IsCoordValid(vx, vy) && farm.At(vx, vy) == region && farm.At(hx, vy) != region {
-						cornerPlots.Set(current.X, current.Y, "#")
-						corners++
-					}
-				}
-			} else {
-				for _, v := range vertical {
-					vx, vy := v.Apply(current.X, current.Y)
-					if !farm.IsCoordValid(vx, vy) || farm.At(vx, vy) != region {
-						cornerPlots.Set(current.X, current.Y, "#")
-						corners++
-					}
-				}
-			}
-		}
-	}
-
-	return corners * len(positions)
+func RegionPriceWithDiscount(r Region[rune]) int {
+	return r.Geometry().Sides * r.Area()
 }
 
 func Price(farm Grid[rune]) int {
 	price := 0
 
-	visited := NewGrid[bool](farm.Width, farm.Height)
-
-	for v := range visited.AllCells() {
-		if v.Value {
-			continue
-		}
-		price += RegionPrice(farm, visited, v.X, v.Y)
+	_, regions := NewNeighbors4[rune]().Components(farm, samePlant)
+	for _, r := range regions {
+		price += RegionPrice(r)
 	}
 
 	return price
@@ -162,27 +75,52 @@ func Price(farm Grid[rune]) int {
 func PriceWithDiscount(farm Grid[rune]) int {
 	price := 0
 
-	visited := NewGrid[bool](farm.Width, farm.Height)
+	_, regions := NewNeighbors4[rune]().Components(farm, samePlant)
+	for _, r := range regions {
+		price += RegionPriceWithDiscount(r)
+	}
+
+	return price
+}
+
+// regionGraph links the regions touching each other.
+func regionGraph(farm Grid[rune]) LabeledGraph[int] {
+	labels, regions := NewNeighbors4[rune]().Components(farm, samePlant)
 
-	for v := range visited.AllCells() {
-		if v.Value {
-			continue
+	g := NewUndirectedGraph[int]()
+	for _, r := range regions {
+		g.AddNode(r.Label)
+	}
+	for c := range labels.AllCells() {
+		for _, d := range []Direction{DirRight, DirDown} {
+			x, y := d.Apply(c.X, c.Y)
+			if labels.IsCoordValid(x, y) && labels.At(x, y) != c.Value {
+				g.SetEdge(c.Value, labels.At(x, y), 1)
+			}
 		}
-		price += RegionPriceWithDiscount(farm, visited, v.X, v.Y)
 	}
 
-	return price
+	return LabeledGraph[int]{
+		Graph: g,
+		Label: func(label int) string {
+			r := regions[label]
+			return fmt.Sprintf("%c #%d (%d)", r.Cells[0].Value, label, r.Area())
+		},
+	}
 }
 
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 	log.DefaultLogger.SetLevel(log.InfoLevel)
 
-	input := ReadInput("input.txt")
+	filename := "input.txt"
+	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 	callback(ctx, InputLoaded{Input: input})
 
 	fmt.Println(input.Farm.Stringf(func(r rune) string { return string(r) }))
 
 	callback(ctx, SolutionFound{Part: 1, Solution: Price(input.Farm)})
 	callback(ctx, SolutionFound{Part: 2, Solution: PriceWithDiscount(input.Farm)})
+	callback(ctx, GraphBuilt{Name: "regions", Build: func() GraphExporter { return regionGraph(input.Farm) }})
 
 }
@@ -4,10 +4,12 @@ import (
 	"bufio"
 	"context"
 	"embed"
+	"math"
 	"regexp"
 	"strconv"
 
 	. "github.com/gverger/aoc2024/utils"
+	"github.com/gverger/aoc2024/utils/mathx"
 	"github.com/phuslu/log"
 )
 
@@ -71,46 +73,100 @@ type SolutionFound struct {
 	Solution int
 }
 
+func cross(u, v Axes) int {
+	return u.X*v.Y - u.Y*v.X
+}
+
 func IsParallel(m Machine) bool {
-	return m.A.X*m.B.Y-m.B.X*m.A.Y == 0
+	return cross(m.A, m.B) == 0
 }
 
+// IsSameLine tells if both buttons move along the line to the price.
 func IsSameLine(m Machine) bool {
-	return m.Price.X == m.Price.Y && IsParallel(m)
+	return IsParallel(m) && cross(m.A, m.Price) == 0 && cross(m.B, m.Price) == 0
 }
 
-func IntegerIntersection(m Machine) (int, int, bool) {
+func buttonsSystem(m Machine) mathx.LinearSolution {
 	// m.A.X . a + m.B.X . b = m.P.X
 	// m.A.Y . a + m.B.Y . b = m.P.Y
+	return mathx.SolveLinear([][]int{{m.A.X, m.B.X}, {m.A.Y, m.B.Y}}, []int{m.Price.X, m.Price.Y})
+}
 
-	denom := m.A.X*m.B.Y - m.B.X*m.A.Y
-	a := m.Price.X*m.B.Y - m.Price.Y*m.B.X
-	if a%denom != 0 {
+// IntegerIntersection returns the presses when only one combination of the
+// buttons reaches the price, and it is integral.
+func IntegerIntersection(m Machine) (int, int, bool) {
+	presses, ok := buttonsSystem(m).IntegerSolution()
+	if !ok {
 		return 0, 0, false
 	}
-	b := m.Price.Y*m.A.X - m.Price.X*m.A.Y
-	if b%denom != 0 {
+	return presses[0], presses[1], true
+}
+
+// cheapestOnLine finds the cheapest presses when both buttons move along the
+// line to the price: the integer solutions are base + k.step, and the cost
+// 3a + b is linear in k, so the cheapest is at an end of the valid range of k.
+func cheapestOnLine(solution mathx.LinearSolution) (int, int, bool) {
+	if len(solution.Free) > 1 {
+		// Buttons that do not move, and a price at the origin.
+		return 0, 0, true
+	}
+	base, step, ok := solution.IntegerSolutions()
+	if !ok {
+		return 0, 0, false
+	}
+
+	// Presses cannot be negative: base[i] + k.step[i] >= 0.
+	minK, maxK := math.MinInt, math.MaxInt
+	for i := range base {
+		switch {
+		case step[i] > 0:
+			minK = max(minK, mathx.CeilDiv(-base[i], step[i]))
+		case step[i] < 0:
+			maxK = min(maxK, mathx.FloorDiv(base[i], -step[i]))
+		case base[i] < 0:
+			return 0, 0, false
+		}
+	}
+	if minK > maxK {
 		return 0, 0, false
 	}
 
-	return a / denom, b / denom, true
+	// The range is bounded on the cheap side, as presses only add to the cost.
+	k := minK
+	if 3*step[0]+step[1] < 0 {
+		k = maxK
+	}
+	return base[0] + k*step[0], base[1] + k*step[1], true
+}
+
+// Presses returns the cheapest way to win the price, if any.
+func Presses(m Machine) (int, int, bool) {
+	solution := buttonsSystem(m)
+	switch solution.Kind {
+	case mathx.UniqueSolution:
+		presses, ok := solution.IntegerSolution()
+		if !ok || presses[0] < 0 || presses[1] < 0 {
+			return 0, 0, false
+		}
+		return presses[0], presses[1], true
+	case mathx.InfiniteSolutions:
+		log.Debug().Interface("machine", m).Msg("same line")
+		return cheapestOnLine(solution)
+	}
+	return 0, 0, false
 }
 
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 	log.DefaultLogger.SetLevel(log.InfoLevel)
 
-	input := ReadInput("sample.txt")
+	filename := "sample.txt"
+	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 	callback(ctx, InputLoaded{Input: input})
 
 	sum := 0
 	for _, m := range input.Machines {
-		if IsParallel(m) {
-			log.Info().Interface("machine", m).Msg("parallel")
-		}
-		if IsSameLine(m) {
-			log.Info().Interface("machine", m).Msg("same line")
-		}
-		if a, b, ok := IntegerIntersection(m); ok {
+		if a, b, ok := Presses(m); ok {
 			sum += 3*a + b
 		}
 	}
@@ -120,19 +176,11 @@ func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 	added := 10000000000000
 	sum = 0
 	for _, m := range input.Machines {
-		if IsParallel(m) {
-			log.Info().Interface("machine", m).Msg("parallel")
-			continue
-		}
-		if IsSameLine(m) {
-			log.Error().Interface("machine", m).Msg("same line: not implemented")
-			continue
-		}
 		m.Price = Axes{
 			X: m.Price.X + added,
 			Y: m.Price.Y + added,
 		}
-		if a, b, ok := IntegerIntersection(m); ok {
+		if a, b, ok := Presses(m); ok {
 			sum += 3*a + b
 		}
 	}
@@ -0,0 +1,70 @@
+package day13_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/day13"
+	"github.com/matryer/is"
+)
+
+func TestPresses(t *testing.T) {
+	is := is.New(t)
+
+	a, b, ok := day13.Presses(day13.Machine{A: day13.Axes{X: 94, Y: 34}, B: day13.Axes{X: 22, Y: 67}, Price: day13.Axes{X: 8400, Y: 5400}})
+	is.True(ok)
+	is.Equal(a, 80)
+	is.Equal(b, 40)
+}
+
+func TestPressesOnLine(t *testing.T) {
+	is := is.New(t)
+
+	// B is cheaper per distance: as few A as possible.
+	m := day13.Machine{A: day13.Axes{X: 4, Y: 2}, B: day13.Axes{X: 6, Y: 3}, Price: day13.Axes{X: 34, Y: 17}}
+	is.True(day13.IsSameLine(m))
+	a, b, ok := day13.Presses(m)
+	is.True(ok)
+	is.Equal(a, 1)
+	is.Equal(b, 5)
+
+	// A is cheaper per distance: as many A as possible.
+	m = day13.Machine{A: day13.Axes{X: 10, Y: 10}, B: day13.Axes{X: 2, Y: 2}, Price: day13.Axes{X: 34, Y: 34}}
+	a, b, ok = day13.Presses(m)
+	is.True(ok)
+	is.Equal(a, 3)
+	is.Equal(b, 2)
+
+	// Not reachable with integer presses.
+	m = day13.Machine{A: day13.Axes{X: 4, Y: 2}, B: day13.Axes{X: 6, Y: 3}, Price: day13.Axes{X: 35, Y: 17}}
+	_, _, ok = day13.Presses(m)
+	is.True(!ok)
+
+	// Parallel, but away from the price line.
+	m = day13.Machine{A: day13.Axes{X: 4, Y: 2}, B: day13.Axes{X: 6, Y: 3}, Price: day13.Axes{X: 34, Y: 18}}
+	is.True(!day13.IsSameLine(m))
+	_, _, ok = day13.Presses(m)
+	is.True(!ok)
+
+	// Buttons going in opposite directions: pressing both only adds cost.
+	m = day13.Machine{A: day13.Axes{X: 1, Y: 1}, B: day13.Axes{X: -1, Y: -1}, Price: day13.Axes{X: 3, Y: 3}}
+	a, b, ok = day13.Presses(m)
+	is.True(ok)
+	is.Equal([]int{a, b}, []int{3, 0})
+
+	// Buttons that do not move only win a price at the origin.
+	m = day13.Machine{Price: day13.Axes{X: 5, Y: 0}}
+	_, _, ok = day13.Presses(m)
+	is.True(!ok)
+	m = day13.Machine{}
+	a, b, ok = day13.Presses(m)
+	is.True(ok)
+	is.Equal(a+b, 0)
+}
+
+func TestPressesFraction(t *testing.T) {
+	is := is.New(t)
+
+	m := day13.Machine{A: day13.Axes{X: 26, Y: 66}, B: day13.Axes{X: 67, Y: 21}, Price: day13.Axes{X: 12748, Y: 12176}}
+	_, _, ok := day13.Presses(m)
+	is.True(!ok)
+}
@@ -14,26 +14,13 @@ import (
 //go:embed *.txt
 var f embed.FS
 
-type Pos struct {
-	X int
-	Y int
-}
-
 type Robot struct {
-	Position  Pos
+	Position  Point
 	Direction Direction
 }
 
-func (r Robot) PositionInTurn(n int) Pos {
-	d := Direction{
-		Dx: r.Direction.Dx * n,
-		Dy: r.Direction.Dy * n,
-	}
-	x, y := d.Apply(r.Position.X, r.Position.Y)
-	return Pos{
-		X: x,
-		Y: y,
-	}
+func (r Robot) PositionInTurn(n int) Point {
+	return r.Position.Move(r.Direction.Scale(n))
 }
 
 type Input struct {
@@ -54,7 +41,7 @@ func ReadInput(filename string) Input {
 		Assert(len(numbers) == 4, "need 4 numbers per line: %q -> %v", scanner.Text(), numbers)
 
 		r := Robot{
-			Position: Pos{
+			Position: Point{
 				X: Must(strconv.Atoi(numbers[0])),
 				Y: Must(strconv.Atoi(numbers[1])),
 			},
@@ -86,13 +73,13 @@ type SolutionFound struct {
 
 type StateUpdated struct {
 	Turn      int
-	Positions []Pos
+	Positions []Point
 	Width     int
 	Height    int
 }
 
-func PositionsAtTurn(input Input, turn int) []Pos {
-	positions := make([]Pos, 0, len(input.Robots))
+func PositionsAtTurn(input Input, turn int) []Point {
+	positions := make([]Point, 0, len(input.Robots))
 	for _, r := range input.Robots {
 		p := r.PositionInTurn(turn)
 		positions = append(positions, p)
@@ -115,6 +102,7 @@ func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 	}
 
 	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 
 	callback(ctx, InputLoaded{Input: input, Width: width, Height: height})
 
@@ -128,27 +116,24 @@ func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 		})
 	}
 
-	positions := PositionsAtTurn(input, 100)
+	counts := CountWrapped(uint(width), uint(height), PositionsAtTurn(input, 100), Point.XY)
 
 	quadrants := [4]int{0, 0, 0, 0}
-	for _, p := range positions {
-		x := Mod(p.X, width)
-		y := Mod(p.Y, height)
-
-		if x == width/2 || y == height/2 {
+	for c := range counts.AllCells() {
+		if c.X == width/2 || c.Y == height/2 {
 			continue
 		}
 
 		quadrant := 0
-		if x > width/2 {
+		if c.X > width/2 {
 			quadrant += 1
 		}
 
-		if y > height/2 {
+		if c.Y > height/2 {
 			quadrant += 2
 		}
 
-		quadrants[quadrant]++
+		quadrants[quadrant] += c.Value
 	}
 
 	solution1 := 1
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"embed"
+	"slices"
 	"sort"
 
 	. "github.com/gverger/aoc2024/utils"
@@ -26,14 +27,9 @@ const (
 	Highlighted
 )
 
-type Pos struct {
-	X int
-	Y int
-}
-
 type Input struct {
 	Grid   *Grid[CellType]
-	Player Pos
+	Player Point
 	Moves  []Direction
 }
 
@@ -57,7 +53,7 @@ func createPart2(input Input) Input {
 	}
 	return Input{
 		Grid:   g,
-		Player: Pos{X: 2 * input.Player.X, Y: input.Player.Y},
+		Player: Point{X: 2 * input.Player.X, Y: input.Player.Y},
 		Moves:  input.Moves,
 	}
 
@@ -82,7 +78,7 @@ func ReadInput(filename string) Input {
 
 	g := NewGrid[CellType](uint(len(lines[0])), uint(len(lines)))
 
-	var player Pos
+	var player Point
 
 	for j := 0; j < len(lines); j++ {
 		for i := 0; i < len(lines[j]); i++ {
@@ -95,7 +91,7 @@ func ReadInput(filename string) Input {
 			case 'O':
 				value = Box
 			case '@':
-				player = Pos{X: i, Y: j}
+				player = Point{X: i, Y: j}
 				value = Player
 			}
 			g.Set(i, j, value)
@@ -105,15 +101,8 @@ func ReadInput(filename string) Input {
 	moves := make([]Direction, 0)
 	for scanner.Scan() {
 		for _, c := range scanner.Text() {
-			switch c {
-			case 'v':
-				moves = append(moves, DirDown)
-			case '^':
-				moves = append(moves, DirUp)
-			case '>':
-				moves = append(moves, DirRight)
-			case '<':
-				moves = append(moves, DirLeft)
+			if d, ok := ParseDirection(c); ok {
+				moves = append(moves, d)
 			}
 		}
 	}
@@ -157,17 +146,17 @@ func move(input *Input, dir Direction) {
 	Assert(g.At(x, y) == Empty, "should be an empty cell behind")
 
 	g.Set(input.Player.X, input.Player.Y, Empty)
-	input.Player = Pos{px, py}
+	input.Player = Point{X: px, Y: py}
 	g.Set(px, py, Player)
 	if x != px || y != py {
 		g.Set(x, y, Box)
 	}
 }
 
-func pushedBoxes(g Grid[CellType], x, y int, dir Direction) (Set[Pos], bool) {
+func pushedBoxes(g Grid[CellType], x, y int, dir Direction) (Set[Point], bool) {
 	nx, ny := dir.Apply(x, y)
 
-	positions := NewSet[Pos]()
+	positions := NewSet[Point]()
 
 	cell := g.At(nx, ny)
 
@@ -176,15 +165,15 @@ func pushedBoxes(g Grid[CellType], x, y int, dir Direction) (Set[Pos], bool) {
 	}
 
 	if cell&Empty != 0 {
-		return NewSet[Pos](), true
+		return NewSet[Point](), true
 	}
 
 	if cell&Right != 0 {
 		nx--
 	}
 
-	positions.Add(Pos{nx, ny})
-	positions.Add(Pos{nx + 1, ny})
+	positions.Add(Point{X: nx, Y: ny})
+	positions.Add(Point{X: nx + 1, Y: ny})
 
 	if dir.Dy != 0 {
 		if pos, ok := pushedBoxes(g, nx, ny, dir); ok {
@@ -225,10 +214,7 @@ func move2(input *Input, dir Direction) {
 		return
 	}
 
-	pos := make([]Pos, 0, len(pushed))
-	for p := range pushed {
-		pos = append(pos, p)
-	}
+	pos := slices.Collect(pushed.All())
 
 	sort.Slice(pos, func(i, j int) bool {
 		di := pos[i].X*dir.Dx + pos[i].Y*dir.Dy
@@ -242,14 +228,16 @@ func move2(input *Input, dir Direction) {
 		g.Set(p.X, p.Y, Empty)
 	}
 	g.Set(input.Player.X, input.Player.Y, Empty)
-	input.Player = Pos{px, py}
+	input.Player = Point{X: px, Y: py}
 	g.Set(px, py, Player)
 }
 
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 	log.DefaultLogger.SetLevel(log.InfoLevel)
 
-	input := ReadInput("input.txt")
+	filename := "input.txt"
+	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 	// callback(ctx, InputLoaded{Input: input})
 	//
 	// for _, m := range input.Moves {
@@ -2,11 +2,14 @@ package day16
 
 import (
 	"bufio"
+	"cmp"
 	"context"
 	"embed"
+	"fmt"
 	"slices"
 
 	. "github.com/gverger/aoc2024/utils"
+	"github.com/phuslu/log"
 )
 
 //go:embed *.txt
@@ -22,21 +25,30 @@ const (
 
 type Input struct {
 	Grid     *Grid[CellType]
-	Start    Pos
+	Start    Point
 	StartDir Direction
-	End      Pos
-}
-
-type Pos struct {
-	X int
-	Y int
+	End      Point
 }
 
 type Reindeer struct {
-	Pos Pos
+	Pos Point
 	Dir Direction
 }
 
+func (r Reindeer) String() string {
+	return fmt.Sprintf("%d,%d %v", r.Pos.X, r.Pos.Y, r.Dir)
+}
+
+// compareReindeers orders reindeers by row, column, then direction.
+func compareReindeers(a, b Reindeer) int {
+	return cmp.Or(
+		cmp.Compare(a.Pos.Y, b.Pos.Y),
+		cmp.Compare(a.Pos.X, b.Pos.X),
+		cmp.Compare(a.Dir.Dy, b.Dir.Dy),
+		cmp.Compare(a.Dir.Dx, b.Dir.Dx),
+	)
+}
+
 func ReadInput(filename string) Input {
 	file := Must(f.Open(filename))
 	defer file.Close()
@@ -50,7 +62,7 @@ func ReadInput(filename string) Input {
 	AssertNoErr(scanner.Err(), "reading input file")
 
 	g := NewGrid[CellType](uint(len(lines[0])), uint(len(lines)))
-	var start, end Pos
+	var start, end Point
 
 	for j, l := range lines {
 		for i, r := range l {
@@ -61,10 +73,10 @@ func ReadInput(filename string) Input {
 				g.Set(i, j, Empty)
 			case 'S':
 				g.Set(i, j, Empty)
-				start = Pos{i, j}
+				start = Point{X: i, Y: j}
 			case 'E':
 				g.Set(i, j, Empty)
-				end = Pos{i, j}
+				end = Point{X: i, Y: j}
 			}
 		}
 	}
@@ -88,39 +100,22 @@ type SolutionFound struct {
 	Grid     Grid[CellType]
 }
 
-func countParents(parents map[Reindeer]Set[Reindeer], current Reindeer, counted Set[Reindeer]) int {
-	if counted.Exists(current) {
-		return 0
-	}
-	if len(parents[current]) == 0 {
-		return 1
-	}
-	counted.Add(current)
-	sum := 1
-	for p := range parents[current] {
-		sum += countParents(parents, p, counted)
-	}
-	return sum
-}
-
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 	filename := "input.txt"
 	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 
 	callback(ctx, InputLoaded{Input: input})
 
 	start := WithCost[Reindeer, int]{Value: Reindeer{Pos: input.Start, Dir: input.StartDir}, Cost: 0}
 	isDone := func(p Reindeer) bool { return p.Pos == input.End }
 	neighbors := func(r WithCost[Reindeer, int]) []WithCost[Reindeer, int] {
-		turned := []Direction{
-			{Dx: r.Value.Dir.Dy, Dy: r.Value.Dir.Dx},
-			{Dx: -r.Value.Dir.Dy, Dy: -r.Value.Dir.Dx},
-		}
+		turned := []Direction{r.Value.Dir.TurnRight(), r.Value.Dir.TurnLeft()}
 
 		neighbors := make([]WithCost[Reindeer, int], 0)
-		x, y := r.Value.Dir.Apply(r.Value.Pos.X, r.Value.Pos.Y)
-		if input.Grid.IsCoordValid(x, y) && input.Grid.At(x, y) != Wall {
-			reindeer := Reindeer{Pos: Pos{X: x, Y: y}, Dir: r.Value.Dir}
+		next := r.Value.Pos.Move(r.Value.Dir)
+		if input.Grid.IsPointValid(next) && input.Grid.AtP(next) != Wall {
+			reindeer := Reindeer{Pos: next, Dir: r.Value.Dir}
 			neighbors = append(neighbors, WithCost[Reindeer, int]{Value: reindeer, Cost: r.Cost + 1})
 		}
 		neighbors = append(neighbors, WithCost[Reindeer, int]{Value: Reindeer{Pos: r.Value.Pos, Dir: turned[0]}, Cost: r.Cost + 1000})
@@ -129,9 +124,15 @@ func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 		return neighbors
 	}
 
-	p, cost, ok := Dijkstra(start, isDone, neighbors)
+	toEnd := func(r Reindeer) int { return r.Pos.Manhattan(input.End) }
+	observer := NewSearchObserver(ctx, func(e SearchEvent[Reindeer, int]) { callback(ctx, e) })
+	p, cost, expanded, ok := AStar(start, isDone, neighbors, toEnd, observer)
+	if ctx.Err() != nil {
+		return
+	}
 
-	Assert(ok, "dijkstra")
+	Assert(ok, "a*")
+	log.Debug().Int("expanded", expanded).Msg("A* search")
 	g := input.Grid.Clone()
 	for _, r := range p {
 		g.Set(r.Pos.X, r.Pos.Y, Footprints)
@@ -139,8 +140,10 @@ func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 
 	callback(ctx, SolutionFound{Part: 1, Solution: cost, Grid: *g})
 
-	parents, costs := DijkstraAll(start, neighbors)
-	parentsIn := NewSet[Reindeer]()
+	parents, costs := DijkstraAll(start, neighbors, observer)
+	if ctx.Err() != nil {
+		return
+	}
 
 	possibleEnds := []Reindeer{
 		{Pos: input.End, Dir: DirUp},
@@ -151,14 +154,12 @@ func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 
 	endCost := slices.Min(MapTo(possibleEnds, func(r Reindeer) int { return costs[r] }))
 
-	for _, r := range possibleEnds {
-		if costs[r] == endCost {
-			countParents(parents, r, parentsIn)
-		}
-	}
+	bestEnds := Filter(possibleEnds, func(r Reindeer) bool { return costs[r] == endCost })
+	log.Debug().Str("paths", CountPaths(parents, bestEnds...).String()).Msg("best paths")
 
-	positions := NewSet[Pos]()
-	for r := range parentsIn {
+	onBestPaths := PathNodes(parents, bestEnds...)
+	positions := NewSet[Point]()
+	for r := range onBestPaths {
 		positions.Add(r.Pos)
 	}
 
@@ -168,4 +169,9 @@ func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 	}
 
 	callback(ctx, SolutionFound{Part: 2, Solution: len(positions), Grid: *g})
+
+	callback(ctx, GraphBuilt{Name: "best paths", Build: func() GraphExporter {
+		dag := ParentsGraph(parents, compareReindeers).Subgraph(slices.Collect(onBestPaths.SortedFunc(compareReindeers))...)
+		return LabeledGraph[Reindeer]{Graph: dag, Highlight: SetOf(p...)}
+	}})
 }
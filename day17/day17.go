@@ -218,6 +218,7 @@ func unroll(c Computer) (int, bool) {
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 	filename := "input.txt"
 	input := ReadInput(filename)
+	callback(ctx, utils.ReadInputFile(f, filename))
 
 	callback(ctx, InputLoaded{Input: input})
 
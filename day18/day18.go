@@ -15,19 +15,14 @@ import (
 var f embed.FS
 
 type Fall struct {
-	Pos
+	utils.Point
 	At int
 }
 
 type Input struct {
 	Falls []Fall
-	Start Pos
-	End   Pos
-}
-
-type Pos struct {
-	X int
-	Y int
+	Start utils.Point
+	End   utils.Point
 }
 
 func ReadInput(filename string) Input {
@@ -42,7 +37,7 @@ func ReadInput(filename string) Input {
 		step++
 		coords := strings.Split(scanner.Text(), ",")
 		falls = append(falls, Fall{
-			Pos: Pos{
+			Point: utils.Point{
 				X: utils.Must(strconv.Atoi(coords[0])),
 				Y: utils.Must(strconv.Atoi(coords[1])),
 			},
@@ -51,10 +46,10 @@ func ReadInput(filename string) Input {
 	}
 	utils.AssertNoErr(scanner.Err(), "reading input file")
 
-	start := Pos{X: 0, Y: 0}
-	end := Pos{X: 6, Y: 6}
+	start := utils.Point{X: 0, Y: 0}
+	end := utils.Point{X: 6, Y: 6}
 	if filename == "input.txt" {
-		end = Pos{X: 70, Y: 70}
+		end = utils.Point{X: 70, Y: 70}
 	}
 
 	return Input{
@@ -87,28 +82,33 @@ type GridUpdated struct {
 	Grid *utils.Grid[int]
 }
 
-func Part1(ctx context.Context, input Input, limit int, callback func(ctx context.Context, obj any)) {
-	g := gridFromFalls(input.Falls[:limit], input.End.X, input.End.Y)
+func isFree(at int) bool {
+	return at == 0
+}
 
-	callback(ctx, GridUpdated{Grid: g})
+// shortestPath finds a path from start to end avoiding the fallen bytes. The
+// search events go to the callback, and the search stops when ctx is done.
+func shortestPath(ctx context.Context, g utils.Grid[int], input Input, callback func(ctx context.Context, obj any)) ([]utils.Cell[int], int, bool) {
+	isEnd := func(c utils.Cell[int]) bool { return c.X == input.End.X && c.Y == input.End.Y }
+	start := []utils.Point{input.Start}
+	observer := utils.NewSearchObserver(ctx, func(e utils.SearchEvent[utils.Point, int]) { callback(ctx, e) })
 
-	start := utils.WithCost[Pos, int]{Value: input.Start, Cost: 0}
-	isDone := func(p Pos) bool { return p == input.End }
+	r, end, ok := utils.BFSDistancesUntil(g, start, isFree, isEnd, observer)
+	if !ok {
+		return nil, 0, false
+	}
+	return r.Path(end.X, end.Y), end.Value, true
+}
 
-	neighbors := func(r utils.WithCost[Pos, int]) []utils.WithCost[Pos, int] {
-		neighbors := make([]utils.WithCost[Pos, int], 0)
+func Part1(ctx context.Context, input Input, limit int, callback func(ctx context.Context, obj any)) {
+	g := gridFromFalls(input.Falls[:limit], input.End.X, input.End.Y)
 
-		for _, d := range utils.Dirs4 {
-			x, y := d.Apply(r.Value.X, r.Value.Y)
-			if g.IsCoordValid(x, y) && g.At(x, y) == 0 {
-				neighbors = append(neighbors, utils.WithCost[Pos, int]{Value: Pos{X: x, Y: y}, Cost: r.Cost + 1})
-			}
-		}
+	callback(ctx, GridUpdated{Grid: g})
 
-		return neighbors
+	p, cost, ok := shortestPath(ctx, *g, input, callback)
+	if ctx.Err() != nil {
+		return
 	}
-
-	p, cost, ok := utils.Dijkstra(start, isDone, neighbors)
 	utils.Assert(ok, "path not found")
 
 	for _, pos := range p {
@@ -124,34 +124,25 @@ func Part2(ctx context.Context, input Input, limit int, callback func(ctx contex
 	g := utils.NewGrid[int](uint(input.End.X+1), uint(input.End.Y+1))
 
 	callback(ctx, GridUpdated{Grid: g})
-	start := utils.WithCost[Pos, int]{Value: input.Start, Cost: 0}
-	isDone := func(p Pos) bool { return p == input.End }
-
-	neighbors := func(r utils.WithCost[Pos, int]) []utils.WithCost[Pos, int] {
-		neighbors := make([]utils.WithCost[Pos, int], 0)
-
-		for _, d := range utils.Dirs4 {
-			x, y := d.Apply(r.Value.X, r.Value.Y)
-			if g.IsCoordValid(x, y) && g.At(x, y) == 0 {
-				neighbors = append(neighbors, utils.WithCost[Pos, int]{Value: Pos{X: x, Y: y}, Cost: r.Cost + 1})
-			}
-		}
-
-		return neighbors
-	}
 
 	i := 0
 	for i < len(input.Falls) {
 		f := input.Falls[i]
 		g.Set(f.X, f.Y, i+1)
 
-		p, _, ok := utils.Dijkstra(start, isDone, neighbors)
+		p, _, ok := shortestPath(ctx, *g, input, callback)
+		if ctx.Err() != nil {
+			return
+		}
 
 		if !ok {
 			gWithPath := g.Clone()
 
 			g.Set(f.X, f.Y, 0)
-			p, _, ok := utils.Dijkstra(start, isDone, neighbors)
+			p, _, ok := shortestPath(ctx, *g, input, callback)
+			if ctx.Err() != nil {
+				return
+			}
 			utils.Assert(ok, "rerun n-1")
 			g.Set(f.X, f.Y, i+1)
 
@@ -188,6 +179,7 @@ func Part2(ctx context.Context, input Input, limit int, callback func(ctx contex
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 	filename := "input.txt"
 	input := ReadInput(filename)
+	callback(ctx, utils.ReadInputFile(f, filename))
 	callback(ctx, InputLoaded{Input: input})
 
 	limit := 12
@@ -51,6 +51,8 @@ type model struct {
 	grid utils.Grid[day20.CellType]
 	sol1 int
 	sol2 int
+	// settled counts the nodes settled by the searches.
+	settled int
 
 	changes chan Change
 	done    chan Done
@@ -70,6 +72,10 @@ func (m *model) callback(ctx context.Context, event any) {
 		m.changes <- Change{Event: e}
 	case day20.SolutionFound:
 		m.changes <- Change{Event: e}
+	case utils.SearchEvent[utils.Point, int]:
+		if e.Kind == utils.SearchSettle {
+			m.changes <- Change{Event: e}
+		}
 	}
 }
 
@@ -95,6 +101,8 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case Change:
 		switch e := msg.Event.(type) {
+		case utils.SearchEvent[utils.Point, int]:
+			m.settled++
 		case day20.InputLoaded:
 			m.grid = *e.Input.Grid
 		case day20.SolutionFound:
@@ -134,6 +142,7 @@ func (m model) View() string {
 	sb.WriteRune('\n')
 	sb.WriteString(fmt.Sprintf("Solution 1: %v\n", m.sol1))
 	sb.WriteString(fmt.Sprintf("Solution 2: %v\n", m.sol2))
+	sb.WriteString(fmt.Sprintf("Settled: %d\n", m.settled))
 
 	return sb.String()
 }
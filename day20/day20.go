@@ -21,13 +21,8 @@ const (
 
 type Input struct {
 	Grid  *utils.Grid[CellType]
-	Start Pos
-	End   Pos
-}
-
-type Pos struct {
-	X int
-	Y int
+	Start utils.Point
+	End   utils.Point
 }
 
 func ReadInput(filename string) Input {
@@ -43,7 +38,7 @@ func ReadInput(filename string) Input {
 	utils.AssertNoErr(scanner.Err(), "reading input file")
 
 	g := utils.NewGrid[CellType](uint(len(lines[0])), uint(len(lines)))
-	var start, end Pos
+	var start, end utils.Point
 
 	for j, l := range lines {
 		for i, r := range l {
@@ -54,10 +49,10 @@ func ReadInput(filename string) Input {
 				g.Set(i, j, Empty)
 			case 'S':
 				g.Set(i, j, Empty)
-				start = Pos{i, j}
+				start = utils.Point{X: i, Y: j}
 			case 'E':
 				g.Set(i, j, Empty)
-				end = Pos{i, j}
+				end = utils.Point{X: i, Y: j}
 			}
 		}
 	}
@@ -81,33 +76,23 @@ type SolutionFound struct {
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 	filename := "input.txt"
 	input := ReadInput(filename)
+	callback(ctx, utils.ReadInputFile(f, filename))
 	callback(ctx, InputLoaded{Input: input})
 
 	g := input.Grid
 
-	start := utils.WithCost[Pos, int]{Value: input.Start, Cost: 0}
-	isDone := func(p Pos) bool { return p == input.End }
-
-	neighbors := func(r utils.WithCost[Pos, int]) []utils.WithCost[Pos, int] {
-		neighbors := make([]utils.WithCost[Pos, int], 0)
-
-		for _, d := range utils.Dirs4 {
-			x, y := d.Apply(r.Value.X, r.Value.Y)
-			if g.IsCoordValid(x, y) && g.At(x, y) == 0 {
-				neighbors = append(neighbors, utils.WithCost[Pos, int]{Value: Pos{X: x, Y: y}, Cost: r.Cost + 1})
-			}
-		}
+	start := []utils.Point{input.Start}
+	isEmpty := func(c CellType) bool { return c == Empty }
 
-		return neighbors
+	observer := utils.NewSearchObserver(ctx, func(e utils.SearchEvent[utils.Point, int]) { callback(ctx, e) })
+	r := utils.BFSDistances(*g, start, isEmpty, observer)
+	if ctx.Err() != nil {
+		return
 	}
+	path := r.Path(input.End.X, input.End.Y)
+	utils.Assert(len(path) > 0, "path not found")
 
-	path, _, ok := utils.Dijkstra(start, isDone, neighbors)
-	utils.Assert(ok, "path not found")
-
-	step := utils.NewGrid[int](g.Width, g.Height)
-	for i, p := range path {
-		step.Set(p.X, p.Y, i)
-	}
+	step := r.Distances
 
 	parts := []struct {
 		dist    int
@@ -50,32 +50,30 @@ func ReadInput(filename string) Input {
 func isXmas(g Grid[string], x, y int, d Direction) bool {
 	word := "XMAS"
 
-	for i := 0; i < len(word); i++ {
-		nx := x + d.Dx*i
-		ny := y + d.Dy*i
-		if !g.IsCoordValid(nx, ny) || g.At(nx, ny) != string(word[i]) {
+	i := 0
+	for c := range g.Ray(x, y, d) {
+		if c.Value != string(word[i]) {
 			return false
 		}
+		i++
+		if i == len(word) {
+			return true
+		}
 	}
-	return true
+	return false
 }
 
-func isMaxInX(g Grid[string], x, y int) bool {
-	if g.At(x, y) != "A" {
-		return false
-	}
-
-	diag1 := g.At(DirUL.Apply(x, y)) + g.At(DirDR.Apply(x, y))
-	if diag1 != "MS" && diag1 != "SM" {
-		return false
-	}
-
-	diag2 := g.At(DirDL.Apply(x, y)) + g.At(DirUR.Apply(x, y))
-	if diag2 != "MS" && diag2 != "SM" {
-		return false
-	}
-	return true
-}
+// masInX is two MAS crossing on their A.
+var masInX = func() Pattern[string] {
+	template := NewGrid[string](3, 3)
+	template.SetAll(".")
+	template.Set(0, 0, "M")
+	template.Set(0, 2, "M")
+	template.Set(1, 1, "A")
+	template.Set(2, 0, "S")
+	template.Set(2, 2, "S")
+	return NewPattern(*template, ".")
+}()
 
 func backOneCell(d Direction, x, y int) (int, int) {
 	return x - d.Dx, y - d.Dy
@@ -118,7 +116,9 @@ func Run(ctx context.Context, listener chan<- Event) error {
 		}
 	}
 
-	input := ReadInput("input.txt")
+	filename := "input.txt"
+	input := ReadInput(filename)
+	notify(ReadInputFile(f, filename))
 	notify(InputLoaded{Input: input})
 
 	// Part 1
@@ -144,16 +144,12 @@ func Run(ctx context.Context, listener chan<- Event) error {
 	// Part 2
 	part2Nb := 0
 
-	for y := 1; y < int(input.Grid.Height)-1; y++ {
-		for x := 1; x < int(input.Grid.Width)-1; x++ {
-			if isMaxInX(input.Grid, x, y) {
-				notify(MasInXFound{X: x, Y: y})
-				part2Nb++
-			}
-		}
+	for m := range masInX.FindAll(input.Grid) {
 		if err := checkDone(ctx); err != nil {
 			return err
 		}
+		notify(MasInXFound{X: m.X + 1, Y: m.Y + 1})
+		part2Nb++
 	}
 
 	notify(SolutionFound{Part: 2, Solution: part2Nb})
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"embed"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -78,31 +79,41 @@ type SolutionFound struct {
 }
 
 func isValid(ordering []int, g Graph[int]) bool {
-	for i, first := range ordering[:len(ordering)-1] {
-		second := ordering[i+1]
-		if g.HasEdge(second, first) {
-			return false
-		}
-	}
-	return true
+	return slices.Equal(reorder(ordering, g), ordering)
 }
 
+// reorder sorts the pages according to the rules between them, leaving them
+// untouched if they are already in order. Pages in no rule are isolated nodes,
+// added in update order like the others so that they keep their relative order.
 func reorder(ordering []int, g Graph[int]) []int {
-	for i := 0; i < len(ordering)-1; i++ {
-		if g.HasEdge(ordering[i+1], ordering[i]) {
-			ordering[i], ordering[i+1] = ordering[i+1], ordering[i]
-			if i >= 1 {
-				i -= 2
+	sub := NewGraph[int]()
+	for _, page := range ordering {
+		if !sub.HasNode(page) {
+			sub.AddNode(page)
+		}
+	}
+	for _, a := range sub.Nodes {
+		for _, b := range sub.Nodes {
+			if g.HasEdge(a, b) {
+				sub.AddEdge(a, b)
 			}
 		}
 	}
-	return ordering
+
+	sorted, ok := sub.TopologicalSort()
+	Assert(ok, "cycle in rules for %v", ordering)
+	return sorted
 }
 
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 
-	input := ReadInput("input.txt")
+	filename := "input.txt"
+	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 	callback(ctx, InputLoaded{Input: input})
+	callback(ctx, GraphBuilt{Name: "rules", Build: func() GraphExporter {
+		return LabeledGraph[int]{Graph: &input.Graph}
+	}})
 
 	sum1 := 0
 	for _, o := range input.Orderings {
@@ -37,16 +37,7 @@ func (g *Guard) StepForward() {
 }
 
 func (g *Guard) TurnRight() {
-	switch g.Dir {
-	case DirUp:
-		g.Dir = DirRight
-	case DirRight:
-		g.Dir = DirDown
-	case DirDown:
-		g.Dir = DirLeft
-	case DirLeft:
-		g.Dir = DirUp
-	}
+	g.Dir = g.Dir.TurnRight()
 }
 
 type Input struct {
@@ -135,12 +126,15 @@ func run(ctx context.Context, input Input, callback func(ctx context.Context, ob
 	for {
 		oldx, oldy := guard.X, guard.Y
 
-		for g.IsCoordValid(guard.PositionAfterStep()) && g.At(guard.PositionAfterStep()) != ObstacleCell {
-			visited.Set(guard.X, guard.Y, true)
-			if g.At(guard.X, guard.Y) != FootPrintsCell {
-				g.Set(guard.X, guard.Y, FootPrintsCell)
+		for c := range g.Ray(guard.X, guard.Y, guard.Dir) {
+			if c.Value == ObstacleCell {
+				break
+			}
+			visited.Set(c.X, c.Y, true)
+			if c.Value != FootPrintsCell {
+				g.Set(c.X, c.Y, FootPrintsCell)
 			}
-			guard.StepForward()
+			guard.X, guard.Y = c.X, c.Y
 		}
 
 		callback(
@@ -155,10 +149,6 @@ func run(ctx context.Context, input Input, callback func(ctx context.Context, ob
 		)
 
 		if !g.IsCoordValid(guard.PositionAfterStep()) {
-			visited.Set(guard.X, guard.Y, true)
-			if g.At(guard.X, guard.Y) != FootPrintsCell {
-				g.Set(guard.X, guard.Y, FootPrintsCell)
-			}
 			break
 		}
 
@@ -182,7 +172,9 @@ func run(ctx context.Context, input Input, callback func(ctx context.Context, ob
 
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 
-	input := ReadInput("input.txt")
+	filename := "input.txt"
+	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 	callback(ctx, InputLoaded{Input: input})
 
 	visited, result1 := run(ctx, Input{Grid: *input.Grid.Clone(), Guard: input.Guard}, callback)
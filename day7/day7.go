@@ -130,7 +130,9 @@ func finishesWith(number, end int) bool {
 
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 
-	input := ReadInput("input.txt")
+	filename := "input.txt"
+	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 	callback(ctx, InputLoaded{Input: input})
 
 	sum1 := 0
@@ -54,11 +54,6 @@ type SolutionFound struct {
 	Solution int
 }
 
-type Point struct {
-	X int
-	Y int
-}
-
 func antennas(g Grid[Antenna]) map[Antenna][]Point {
 	antennas := make(map[Antenna][]Point)
 	for y := g.MinY; y <= g.MaxY; y++ {
@@ -82,13 +77,13 @@ func antinodes1(g Grid[Antenna]) Grid[bool] {
 	for _, points := range antennas {
 		for i, p1 := range points {
 			for _, p2 := range points[i+1:] {
-				antinode1 := Point{X: 2*p1.X - p2.X, Y: 2*p1.Y - p2.Y}
-				if antinodes.IsCoordValid(antinode1.X, antinode1.Y) {
-					antinodes.Set(antinode1.X, antinode1.Y, true)
+				antinode1 := p1.Scale(2).Sub(p2)
+				if antinodes.IsPointValid(antinode1) {
+					antinodes.SetP(antinode1, true)
 				}
-				antinode2 := Point{X: 2*p2.X - p1.X, Y: 2*p2.Y - p1.Y}
-				if antinodes.IsCoordValid(antinode2.X, antinode2.Y) {
-					antinodes.Set(antinode2.X, antinode2.Y, true)
+				antinode2 := p2.Scale(2).Sub(p1)
+				if antinodes.IsPointValid(antinode2) {
+					antinodes.SetP(antinode2, true)
 				}
 			}
 		}
@@ -105,24 +100,11 @@ func antinodes2(g Grid[Antenna]) Grid[bool] {
 	for _, points := range antennas {
 		for i, p1 := range points {
 			for _, p2 := range points[i+1:] {
-				mult := 0
-				for {
-					node := Point{X: p1.X + mult*(p1.X-p2.X), Y: p1.Y + mult*(p1.Y-p2.Y)}
-					if !antinodes.IsCoordValid(node.X, node.Y) {
-						break
-					}
-					antinodes.Set(node.X, node.Y, true)
-					mult++
+				for c := range antinodes.Ray(p1.X, p1.Y, p1.Sub(p2).Direction()) {
+					antinodes.Set(c.X, c.Y, true)
 				}
-
-				mult = 0
-				for {
-					node := Point{X: p2.X + mult*(p2.X-p1.X), Y: p2.Y + mult*(p2.Y-p1.Y)}
-					if !antinodes.IsCoordValid(node.X, node.Y) {
-						break
-					}
-					antinodes.Set(node.X, node.Y, true)
-					mult++
+				for c := range antinodes.Ray(p2.X, p2.Y, p2.Sub(p1).Direction()) {
+					antinodes.Set(c.X, c.Y, true)
 				}
 			}
 		}
@@ -133,7 +115,9 @@ func antinodes2(g Grid[Antenna]) Grid[bool] {
 
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 
-	input := ReadInput("input.txt")
+	filename := "input.txt"
+	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 	callback(ctx, InputLoaded{Input: input})
 
 	fmt.Println(input.Grid)
@@ -140,7 +140,9 @@ func compactWholeFiles(_ context.Context, diskmap DiskMap) int64 {
 
 func Run(ctx context.Context, callback func(ctx context.Context, obj any)) {
 
-	input := ReadInput("input.txt")
+	filename := "input.txt"
+	input := ReadInput(filename)
+	callback(ctx, ReadInputFile(f, filename))
 	callback(ctx, InputLoaded{Input: input})
 
 	diskmap := make(DiskMap, len(input.DiskMap))
@@ -1,8 +1,8 @@
 package main
 
 import (
+	"flag"
 	"os"
-	"strconv"
 
 	"github.com/gverger/aoc2024/aoc"
 	"github.com/gverger/aoc2024/aoc/day4"
@@ -24,9 +24,24 @@ import (
 	day8cli "github.com/gverger/aoc2024/cli/day8"
 	day9cli "github.com/gverger/aoc2024/cli/day9"
 	"github.com/gverger/aoc2024/day1"
+	"github.com/gverger/aoc2024/day10"
+	"github.com/gverger/aoc2024/day11"
+	"github.com/gverger/aoc2024/day12"
+	"github.com/gverger/aoc2024/day13"
+	"github.com/gverger/aoc2024/day14"
+	"github.com/gverger/aoc2024/day15"
+	"github.com/gverger/aoc2024/day16"
+	"github.com/gverger/aoc2024/day17"
+	"github.com/gverger/aoc2024/day18"
 	"github.com/gverger/aoc2024/day2"
+	"github.com/gverger/aoc2024/day20"
 	"github.com/gverger/aoc2024/day3"
-	"github.com/gverger/aoc2024/utils"
+	day4solver "github.com/gverger/aoc2024/day4"
+	"github.com/gverger/aoc2024/day5"
+	"github.com/gverger/aoc2024/day6"
+	"github.com/gverger/aoc2024/day7"
+	"github.com/gverger/aoc2024/day8"
+	"github.com/gverger/aoc2024/day9"
 	"github.com/phuslu/log"
 )
 
@@ -46,27 +61,26 @@ func gui() {
 	a.Run()
 }
 
-func console(day string) {
-	cli := cli.NewApp(cli.AppConfig{})
-	cli.RegisterDay(4, day4cli.NewApp(cli))
-	cli.RegisterDay(5, day5cli.NewApp(cli))
-	cli.RegisterDay(6, day6cli.NewApp(cli))
-	cli.RegisterDay(7, day7cli.NewApp(cli))
-	cli.RegisterDay(8, day8cli.NewApp(cli))
-	cli.RegisterDay(9, day9cli.NewApp(cli))
-	cli.RegisterDay(10, day10cli.NewApp(cli))
-	cli.RegisterDay(11, day11cli.NewApp(cli))
-	cli.RegisterDay(12, day12cli.NewApp(cli))
-	cli.RegisterDay(13, day13cli.NewApp(cli))
-	cli.RegisterDay(14, day14cli.NewApp(cli))
-	cli.RegisterDay(15, day15cli.NewApp(cli))
-	cli.RegisterDay(16, day16cli.NewApp(cli))
-	cli.RegisterDay(17, day17cli.NewApp(cli))
-	cli.RegisterDay(18, day18cli.NewApp(cli))
-	cli.RegisterDay(20, day20cli.NewApp(cli))
+func console(day int, format string, watch bool, graphFile string) {
+	app := cli.NewApp(cli.AppConfig{Format: format, Watch: watch, GraphFile: graphFile})
+	app.RegisterDay(4, day4cli.NewApp(app), cli.ChannelSolver(day4solver.Run))
+	app.RegisterDay(5, day5cli.NewApp(app), day5.Run)
+	app.RegisterDay(6, day6cli.NewApp(app), day6.Run)
+	app.RegisterDay(7, day7cli.NewApp(app), day7.Run)
+	app.RegisterDay(8, day8cli.NewApp(app), day8.Run)
+	app.RegisterDay(9, day9cli.NewApp(app), day9.Run)
+	app.RegisterDay(10, day10cli.NewApp(app), day10.Run)
+	app.RegisterDay(11, day11cli.NewApp(app), day11.Run)
+	app.RegisterDay(12, day12cli.NewApp(app), day12.Run)
+	app.RegisterDay(13, day13cli.NewApp(app), day13.Run)
+	app.RegisterDay(14, day14cli.NewApp(app), day14.Run)
+	app.RegisterDay(15, day15cli.NewApp(app), day15.Run)
+	app.RegisterDay(16, day16cli.NewApp(app), day16.Run)
+	app.RegisterDay(17, day17cli.NewApp(app), day17.Run)
+	app.RegisterDay(18, day18cli.NewApp(app), day18.Run)
+	app.RegisterDay(20, day20cli.NewApp(app), day20.Run)
 
-	d := utils.Must(strconv.Atoi(day))
-	cli.Run(d)
+	app.Run(day)
 }
 
 func main() {
@@ -81,10 +95,15 @@ func main() {
 		}
 	}
 
-	args := os.Args[1:]
-	log.Debug().Interface("args", args).Msg("Running app")
-	if len(args) > 1 && args[0] == "--cli" {
-		console(args[1])
+	day := flag.Int("cli", 0, "day to run in the terminal instead of the gui")
+	format := flag.String("format", cli.FormatView, "cli output: view or json")
+	watch := flag.Bool("watch", false, "re-run the cli day when its files change")
+	graphFile := flag.String("graph", "", "write the graph of the cli day to this file, .dot or .mmd")
+	flag.Parse()
+
+	log.Debug().Interface("args", os.Args[1:]).Msg("Running app")
+	if *day > 0 {
+		console(*day, *format, *watch, *graphFile)
 	} else {
 		gui()
 	}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"cmp"
+	"slices"
+)
+
+// AStar searches the cheapest path from start to a node verifying isDone. The
+// heuristic estimates the remaining cost to a done node: it must never
+// overestimate it, and not decrease by more than an edge cost from one node to
+// its neighbors. A heuristic returning 0 turns AStar into Dijkstra.
+//
+// It returns the path, its cost and the number of expanded nodes.
+func AStar[T comparable, U cmp.Ordered](start WithCost[T, U], isDone func(T) bool, neighbors func(WithCost[T, U]) []WithCost[T, U], heuristic func(T) U, obs *SearchObserver[T, U]) ([]T, U, int, bool) {
+	path, expanded, ok := aStar(start, isDone, neighbors, heuristic, obs)
+	if !ok {
+		return nil, start.Cost, expanded, false
+	}
+	return MapTo(path, func(n WithCost[T, U]) T { return n.Value }), path[len(path)-1].Cost, expanded, true
+}
+
+// aStar returns the path with the cost to reach each node.
+func aStar[T comparable, U cmp.Ordered](start WithCost[T, U], isDone func(T) bool, neighbors func(WithCost[T, U]) []WithCost[T, U], heuristic func(T) U, obs *SearchObserver[T, U]) ([]WithCost[T, U], int, bool) {
+	var root T
+	pq := NewIndexedPriorityQueue[T, U]()
+	pq.Push(start.Value, start.Cost+heuristic(start.Value))
+	obs.notify(SearchPush, start.Value, root, start.Cost)
+	visited := NewSet[T]()
+	parent := make(map[T]T, 0)
+	cost := map[T]U{start.Value: start.Cost}
+	expanded := 0
+
+	for !pq.IsEmpty() {
+		current := pq.Pop().Value
+		if visited.Exists(current) {
+			continue
+		}
+		if obs.cancelled() {
+			break
+		}
+
+		visited.Add(current)
+		obs.notify(SearchSettle, current, parent[current], cost[current])
+
+		if isDone(current) {
+			path := []WithCost[T, U]{{current, cost[current]}}
+			for node, ok := parent[current]; ok; node, ok = parent[node] {
+				path = append(path, WithCost[T, U]{node, cost[node]})
+			}
+			slices.Reverse(path)
+			return path, expanded, true
+		}
+
+		expanded++
+		for _, n := range neighbors(WithCost[T, U]{current, cost[current]}) {
+			if visited.Exists(n.Value) {
+				continue
+			}
+			obs.notify(SearchRelax, n.Value, current, n.Cost)
+			if c, ok := cost[n.Value]; ok && c <= n.Cost {
+				continue
+			}
+			cost[n.Value] = n.Cost
+			parent[n.Value] = current
+			pq.Update(n.Value, n.Cost+heuristic(n.Value))
+			obs.notify(SearchPush, n.Value, current, n.Cost)
+		}
+	}
+
+	return nil, expanded, false
+}
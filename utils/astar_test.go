@@ -0,0 +1,59 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func TestAStar(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"..........",
+		".#######..",
+		"........#.",
+		"#######.#.",
+		"..........",
+	)
+	start := utils.Point{X: 0, Y: 0}
+	end := utils.Point{X: 0, Y: 4}
+
+	isDone := func(p utils.Point) bool { return p == end }
+	neighbors := func(p utils.WithCost[utils.Point, int]) []utils.WithCost[utils.Point, int] {
+		res := make([]utils.WithCost[utils.Point, int], 0, 4)
+		for _, n := range p.Value.Neighbors4() {
+			if g.IsPointValid(n) && g.AtP(n) != '#' {
+				res = append(res, utils.WithCost[utils.Point, int]{Value: n, Cost: p.Cost + 1})
+			}
+		}
+		return res
+	}
+	manhattan := func(p utils.Point) int { return p.Manhattan(end) }
+	zero := func(p utils.Point) int { return 0 }
+
+	path, cost, expanded, ok := utils.AStar(utils.WithCost[utils.Point, int]{Value: start}, isDone, neighbors, manhattan, nil)
+	is.True(ok)
+	is.Equal(cost, 18)
+	is.Equal(len(path), 19)
+	is.Equal(path[0], start)
+	is.Equal(path[len(path)-1], end)
+	for i := 1; i < len(path); i++ {
+		is.Equal(path[i-1].Manhattan(path[i]), 1)
+	}
+
+	_, dijkstraCost, dijkstraExpanded, ok := utils.AStar(utils.WithCost[utils.Point, int]{Value: start}, isDone, neighbors, zero, nil)
+	is.True(ok)
+	is.Equal(dijkstraCost, cost)
+	is.True(expanded < dijkstraExpanded)
+
+	g.Set(7, 3, '#')
+	_, cost, _, ok = utils.AStar(utils.WithCost[utils.Point, int]{Value: start}, isDone, neighbors, manhattan, nil)
+	is.True(ok)
+	is.Equal(cost, 22)
+
+	g.Set(9, 3, '#')
+	_, _, _, ok = utils.AStar(utils.WithCost[utils.Point, int]{Value: start}, isDone, neighbors, manhattan, nil)
+	is.True(!ok)
+}
@@ -0,0 +1,96 @@
+package utils
+
+import "slices"
+
+// BFSResult holds the distance of each cell to the closest source, -1 when
+// the cell is not reached, and the direction back to the previous cell on a
+// shortest path. Sources and unreached cells have no direction.
+type BFSResult struct {
+	Distances    *Grid[int]
+	Predecessors *Grid[Direction]
+}
+
+// Path returns the cells from a source to (x, y), with their distances. It is
+// empty if (x, y) was not reached.
+func (r BFSResult) Path(x, y int) []Cell[int] {
+	if !r.Distances.IsCoordValid(x, y) || r.Distances.At(x, y) < 0 {
+		return nil
+	}
+
+	path := make([]Cell[int], 0, r.Distances.At(x, y)+1)
+	for {
+		path = append(path, Cell[int]{X: x, Y: y, Value: r.Distances.At(x, y)})
+		d := r.Predecessors.At(x, y)
+		if d == (Direction{}) {
+			break
+		}
+		x, y = r.Predecessors.Apply(d, x, y)
+	}
+	slices.Reverse(path)
+
+	return path
+}
+
+// BFSDistances computes the distances from the sources to every cell, moving
+// between passable 4-neighbors. Paths wrap around wrapping grids.
+func BFSDistances[T any](g Grid[T], sources []Point, passable func(T) bool, obs *SearchObserver[Point, int]) BFSResult {
+	r, _, _ := BFSDistancesUntil(g, sources, passable, nil, obs)
+	return r
+}
+
+// BFSDistancesUntil stops as soon as a cell verifying isTarget is reached, and
+// returns it with its distance. Farther cells are left unreached. A nil isTarget
+// explores the whole grid.
+func BFSDistancesUntil[T any](g Grid[T], sources []Point, passable func(T) bool, isTarget func(Cell[T]) bool, obs *SearchObserver[Point, int]) (BFSResult, Cell[int], bool) {
+	r := BFSResult{
+		Distances:    NewGridEx[int](g.Width, g.Height, g.MinX, g.MinY),
+		Predecessors: NewGridEx[Direction](g.Width, g.Height, g.MinX, g.MinY),
+	}
+	r.Distances.SetAll(-1)
+	r.Distances.Wrap = g.Wrap
+	r.Predecessors.Wrap = g.Wrap
+
+	queue := make([]Cell[T], 0, len(sources))
+	for _, s := range sources {
+		if r.Distances.At(s.X, s.Y) == 0 {
+			continue
+		}
+		r.Distances.Set(s.X, s.Y, 0)
+		queue = append(queue, Cell[T]{X: s.X, Y: s.Y, Value: g.At(s.X, s.Y)})
+		obs.notify(SearchPush, s, Point{}, 0)
+	}
+
+	for idx := 0; idx < len(queue); idx++ {
+		if obs.cancelled() {
+			break
+		}
+		current := queue[idx]
+		dist := r.Distances.At(current.X, current.Y)
+		var parent Point
+		if d := r.Predecessors.At(current.X, current.Y); d != (Direction{}) {
+			parent.X, parent.Y = r.Predecessors.Apply(d, current.X, current.Y)
+		}
+		obs.notify(SearchSettle, current.Point(), parent, dist)
+		if isTarget != nil && isTarget(current) {
+			return r, Cell[int]{X: current.X, Y: current.Y, Value: dist}, true
+		}
+
+		for _, d := range Dirs4 {
+			x, y := g.Apply(d, current.X, current.Y)
+			if !g.IsCoordValid(x, y) || r.Distances.At(x, y) >= 0 {
+				continue
+			}
+			v := g.At(x, y)
+			if !passable(v) {
+				continue
+			}
+			obs.notify(SearchRelax, Point{X: x, Y: y}, current.Point(), dist+1)
+			r.Distances.Set(x, y, dist+1)
+			r.Predecessors.Set(x, y, Direction{Dx: -d.Dx, Dy: -d.Dy})
+			queue = append(queue, Cell[T]{X: x, Y: y, Value: v})
+			obs.notify(SearchPush, Point{X: x, Y: y}, current.Point(), dist+1)
+		}
+	}
+
+	return r, Cell[int]{}, false
+}
@@ -0,0 +1,55 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func isOpen(r rune) bool {
+	return r != '#'
+}
+
+func TestBFSDistances(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"..#.",
+		".##.",
+		"....",
+	)
+
+	r := utils.BFSDistances(*g, []utils.Point{{X: 0, Y: 0}}, isOpen, nil)
+	is.Equal(r.Distances.At(3, 0), 7)
+	is.Equal(r.Distances.At(2, 0), -1) // Walls are never reached
+
+	path := r.Path(3, 0)
+	is.Equal(len(path), 8)
+	is.Equal(path[0], utils.Cell[int]{X: 0, Y: 0, Value: 0})
+	is.Equal(path[7], utils.Cell[int]{X: 3, Y: 0, Value: 7})
+
+	// Both corners are sources
+	r = utils.BFSDistances(*g, []utils.Point{{X: 0, Y: 0}, {X: 3, Y: 0}}, isOpen, nil)
+	is.Equal(r.Distances.At(2, 2), 3)
+	is.Equal(r.Distances.At(3, 2), 2)
+}
+
+func TestBFSDistancesUntil(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"....",
+		"....",
+		"....",
+	)
+
+	isTarget := func(c utils.Cell[rune]) bool { return c.X == 1 && c.Y == 1 }
+	r, target, ok := utils.BFSDistancesUntil(*g, []utils.Point{{X: 0, Y: 0}}, isOpen, isTarget, nil)
+	is.True(ok)
+	is.Equal(target, utils.Cell[int]{X: 1, Y: 1, Value: 2})
+	is.Equal(r.Distances.At(3, 2), -1) // Too far to be explored
+
+	_, _, ok = utils.BFSDistancesUntil(*g, []utils.Point{{X: 0, Y: 0}}, isOpen, func(utils.Cell[rune]) bool { return false }, nil)
+	is.True(!ok)
+}
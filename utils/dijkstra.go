@@ -1,9 +1,6 @@
 package utils
 
-import (
-	"cmp"
-	"slices"
-)
+import "cmp"
 
 type WithCost[T any, U cmp.Ordered] struct {
 	Value T
@@ -15,13 +12,17 @@ type Visit[T any] struct {
 	Parent T
 }
 
-func DijkstraAll[T comparable, U cmp.Ordered](start WithCost[T, U], neighbors func(WithCost[T, U]) []WithCost[T, U]) (map[T]Set[T], map[T]U) {
+func DijkstraAll[T comparable, U cmp.Ordered](start WithCost[T, U], neighbors func(WithCost[T, U]) []WithCost[T, U], obs *SearchObserver[T, U]) (map[T]Set[T], map[T]U) {
+	var root T
 
-	pq := NewPriorityQueue[T, U]()
+	pq := NewIndexedPriorityQueue[T, U]()
 	pq.Push(start.Value, start.Cost)
+	obs.notify(SearchPush, start.Value, root, start.Cost)
 	visited := NewSet[T]()
 	parents := make(map[T]Set[T], 0)
-	cost := make(map[T]U, 0)
+	// first holds the first parent reaching each node at its best cost.
+	first := make(map[T]T, 0)
+	cost := map[T]U{start.Value: start.Cost}
 
 	for !pq.IsEmpty() {
 		current := pq.Pop()
@@ -29,14 +30,26 @@ func DijkstraAll[T comparable, U cmp.Ordered](start WithCost[T, U], neighbors fu
 			continue
 		}
 
+		if obs.cancelled() {
+			break
+		}
+
 		visited.Add(current.Value)
+		obs.notify(SearchSettle, current.Value, first[current.Value], current.Priority)
 
 		for _, n := range neighbors(WithCost[T, U]{current.Value, current.Priority}) {
+			obs.notify(SearchRelax, n.Value, current.Value, n.Cost)
 			if c, ok := cost[n.Value]; !ok || n.Cost <= c {
 				if !ok || n.Cost < c {
 					parents[n.Value] = NewSet[T]()
+					first[n.Value] = current.Value
 					cost[n.Value] = n.Cost
-					pq.Push(n.Value, n.Cost)
+					pq.Update(n.Value, n.Cost)
+					obs.notify(SearchPush, n.Value, current.Value, n.Cost)
+				}
+				// The start has a cost but no parents, until a free edge leads back to it.
+				if parents[n.Value] == nil {
+					parents[n.Value] = NewSet[T]()
 				}
 				parents[n.Value].Add(current.Value)
 			}
@@ -46,10 +59,12 @@ func DijkstraAll[T comparable, U cmp.Ordered](start WithCost[T, U], neighbors fu
 	return parents, cost
 }
 
-func Dijkstra[T comparable, U cmp.Ordered](start WithCost[T, U], isDone func(T) bool, neighbors func(WithCost[T, U]) []WithCost[T, U]) ([]T, U, bool) {
+func Dijkstra[T comparable, U cmp.Ordered](start WithCost[T, U], isDone func(T) bool, neighbors func(WithCost[T, U]) []WithCost[T, U], obs *SearchObserver[T, U]) ([]T, U, bool) {
+	var root T
 
-	pq := NewPriorityQueue[T, U]()
+	pq := NewIndexedPriorityQueue[T, U]()
 	pq.Push(start.Value, start.Cost)
+	obs.notify(SearchPush, start.Value, root, start.Cost)
 	visited := NewSet[T]()
 	parent := make(map[T]T, 0)
 
@@ -59,30 +74,29 @@ func Dijkstra[T comparable, U cmp.Ordered](start WithCost[T, U], isDone func(T)
 			continue
 		}
 
+		if obs.cancelled() {
+			break
+		}
+
 		visited.Add(current.Value)
+		obs.notify(SearchSettle, current.Value, parent[current.Value], current.Priority)
+
+		// Only a settled node has its final cost: a done node found among the
+		// neighbors may still be reached by a cheaper path.
+		if isDone(current.Value) {
+			return pathTo(parent, current.Value), current.Priority, true
+		}
 
 		for _, n := range neighbors(WithCost[T, U]{current.Value, current.Priority}) {
 			if visited.Exists(n.Value) {
 				continue
 			}
+			obs.notify(SearchRelax, n.Value, current.Value, n.Cost)
 
-			if isDone(n.Value) {
-				path := make([]T, 0)
-				path = append(path, n.Value)
-				node := current.Value
-				ok := true
-				for ok {
-					path = append(path, node)
-					node, ok = parent[node]
-				}
-
-				slices.Reverse(path)
-				return path, n.Cost, true
-			}
-
-			if _, ok := visited[n.Value]; !ok {
+			if c, ok := pq.Priority(n.Value); !ok || n.Cost < c {
 				parent[n.Value] = current.Value
-				pq.Push(n.Value, n.Cost)
+				pq.Update(n.Value, n.Cost)
+				obs.notify(SearchPush, n.Value, current.Value, n.Cost)
 			}
 		}
 	}
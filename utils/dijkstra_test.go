@@ -0,0 +1,49 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func TestDijkstraCheapestToDone(t *testing.T) {
+	is := is.New(t)
+
+	// The expensive edge to the goal is found first, from the start.
+	edges := map[string][]utils.WithCost[string, int]{
+		"a": {{Value: "e", Cost: 100}, {Value: "b", Cost: 1}},
+		"b": {{Value: "e", Cost: 1}},
+	}
+	neighbors := func(n utils.WithCost[string, int]) []utils.WithCost[string, int] {
+		return utils.MapTo(edges[n.Value], func(e utils.WithCost[string, int]) utils.WithCost[string, int] {
+			return utils.WithCost[string, int]{Value: e.Value, Cost: n.Cost + e.Cost}
+		})
+	}
+	isDone := func(id string) bool { return id == "e" }
+
+	path, cost, ok := utils.Dijkstra(utils.WithCost[string, int]{Value: "a"}, isDone, neighbors, nil)
+	is.True(ok)
+	is.Equal(cost, 2)
+	is.Equal(path, []string{"a", "b", "e"})
+
+	path, cost, ok = utils.Dijkstra(utils.WithCost[string, int]{Value: "e", Cost: 3}, isDone, neighbors, nil)
+	is.True(ok)
+	is.Equal(cost, 3)
+	is.Equal(path, []string{"e"})
+}
+
+func TestDijkstraAllFreeEdgeToStart(t *testing.T) {
+	is := is.New(t)
+
+	// Two nodes joined both ways by edges weighing nothing.
+	neighbors := func(n utils.WithCost[int, int]) []utils.WithCost[int, int] {
+		return []utils.WithCost[int, int]{{Value: 1 - n.Value, Cost: n.Cost}}
+	}
+
+	parents, cost := utils.DijkstraAll(utils.WithCost[int, int]{Value: 0}, neighbors, nil)
+	is.Equal(cost, map[int]int{0: 0, 1: 0})
+	is.Equal(len(parents), 2)
+	is.True(parents[0].Exists(1))
+	is.True(parents[1].Exists(0))
+}
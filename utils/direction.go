@@ -0,0 +1,131 @@
+package utils
+
+import "fmt"
+
+// Directions are in screen coordinates: y goes down, so turning right from up
+// goes to the right.
+
+func (d Direction) TurnRight() Direction {
+	return Direction{Dx: -d.Dy, Dy: d.Dx}
+}
+
+func (d Direction) TurnLeft() Direction {
+	return Direction{Dx: d.Dy, Dy: -d.Dx}
+}
+
+func (d Direction) Opposite() Direction {
+	return Direction{Dx: -d.Dx, Dy: -d.Dy}
+}
+
+func (d Direction) Scale(n int) Direction {
+	return Direction{Dx: d.Dx * n, Dy: d.Dy * n}
+}
+
+// Index is the position of the direction in Dirs4, to be used as an array key.
+func (d Direction) Index() int {
+	switch d {
+	case DirUp:
+		return 0
+	case DirRight:
+		return 1
+	case DirDown:
+		return 2
+	case DirLeft:
+		return 3
+	}
+	Assert(false, "not a 4-direction: %v", d)
+	return -1
+}
+
+var directionRunes = map[rune]Direction{
+	'^': DirUp, 'v': DirDown, '<': DirLeft, '>': DirRight,
+	'↑': DirUp, '↓': DirDown, '←': DirLeft, '→': DirRight,
+	'↗': DirUR, '↖': DirUL, '↘': DirDR, '↙': DirDL,
+	'U': DirUp, 'D': DirDown, 'L': DirLeft, 'R': DirRight,
+	'N': DirUp, 'S': DirDown, 'W': DirLeft, 'E': DirRight,
+}
+
+// ParseDirection reads arrows (^v<> or unicode ones) and letters (UDLR or
+// NSEW).
+func ParseDirection(r rune) (Direction, bool) {
+	d, ok := directionRunes[r]
+	return d, ok
+}
+
+// Rune is the arrow of the direction, '?' when there is none.
+func (d Direction) Rune() rune {
+	switch d {
+	case DirUp:
+		return '^'
+	case DirDown:
+		return 'v'
+	case DirLeft:
+		return '<'
+	case DirRight:
+		return '>'
+	case DirUR:
+		return '↗'
+	case DirUL:
+		return '↖'
+	case DirDR:
+		return '↘'
+	case DirDL:
+		return '↙'
+	}
+	return '?'
+}
+
+func (d Direction) String() string {
+	if r := d.Rune(); r != '?' {
+		return string(r)
+	}
+	return fmt.Sprintf("(%d,%d)", d.Dx, d.Dy)
+}
+
+// Compass is one of the 8 directions, in the order of Dirs8.
+type Compass int
+
+const (
+	North Compass = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+var compassNames = [...]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// CompassOf gives the compass direction of a unit direction.
+func CompassOf(d Direction) (Compass, bool) {
+	for i, dir := range Dirs8 {
+		if dir == d {
+			return Compass(i), true
+		}
+	}
+	return 0, false
+}
+
+func (c Compass) Direction() Direction {
+	return Dirs8[c]
+}
+
+// TurnRight turns clockwise by 45 degrees.
+func (c Compass) TurnRight() Compass {
+	return Compass(Mod(int(c)+1, 8))
+}
+
+// TurnLeft turns counterclockwise by 45 degrees.
+func (c Compass) TurnLeft() Compass {
+	return Compass(Mod(int(c)-1, 8))
+}
+
+func (c Compass) Opposite() Compass {
+	return Compass(Mod(int(c)+4, 8))
+}
+
+func (c Compass) String() string {
+	return compassNames[c]
+}
@@ -0,0 +1,58 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func TestDirectionTurns(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(utils.DirUp.TurnRight(), utils.DirRight)
+	is.Equal(utils.DirRight.TurnRight(), utils.DirDown)
+	is.Equal(utils.DirUp.TurnLeft(), utils.DirLeft)
+	is.Equal(utils.DirDL.Opposite(), utils.DirUR)
+	is.Equal(utils.DirDR.TurnRight(), utils.DirDL)
+	is.Equal(utils.DirLeft.Scale(3), utils.Direction{Dx: -3, Dy: 0})
+
+	for i, d := range utils.Dirs4 {
+		is.Equal(d.Index(), i)
+	}
+}
+
+func TestDirectionParsing(t *testing.T) {
+	is := is.New(t)
+
+	for _, r := range "^UN↑" {
+		d, ok := utils.ParseDirection(r)
+		is.True(ok)
+		is.Equal(d, utils.DirUp)
+	}
+	d, ok := utils.ParseDirection('W')
+	is.True(ok)
+	is.Equal(d, utils.DirLeft)
+
+	_, ok = utils.ParseDirection('x')
+	is.True(!ok)
+
+	is.Equal(utils.DirDown.String(), "v")
+	is.Equal(utils.DirUL.String(), "↖")
+	is.Equal(utils.Direction{Dx: 2, Dy: -1}.String(), "(2,-1)")
+}
+
+func TestCompass(t *testing.T) {
+	is := is.New(t)
+
+	c, ok := utils.CompassOf(utils.DirDR)
+	is.True(ok)
+	is.Equal(c, utils.SouthEast)
+	is.Equal(c.String(), "SE")
+	is.Equal(utils.North.TurnLeft(), utils.NorthWest)
+	is.Equal(utils.NorthWest.TurnRight(), utils.North)
+	is.Equal(utils.East.Opposite().Direction(), utils.DirLeft)
+
+	_, ok = utils.CompassOf(utils.Direction{Dx: 2})
+	is.True(!ok)
+}
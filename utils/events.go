@@ -1,3 +1,17 @@
 package utils
 
+import "io/fs"
+
 type Event any
+
+// InputRead is sent by days with the content of the input file they solve, so
+// that reports can tell when the input changes.
+type InputRead struct {
+	File string
+	Data []byte
+}
+
+// ReadInputFile reads a whole input file of a day.
+func ReadInputFile(fsys fs.FS, filename string) InputRead {
+	return InputRead{File: filename, Data: Must(fs.ReadFile(fsys, filename))}
+}
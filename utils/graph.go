@@ -1,17 +1,29 @@
 package utils
 
+import "iter"
+
 type ID any
 
+// Graph edges hold a weight, 1 unless given. In an undirected graph, each edge
+// is stored both ways.
 type Graph[ID comparable] struct {
-	Nodes  []ID
-	Lookup map[ID]int
-	Edges  []map[int]struct{}
+	Nodes      []ID
+	Lookup     map[ID]int
+	Edges      []map[int]int
+	Undirected bool
+
+	// in holds, for each node, the nodes with an edge to it.
+	in []Set[int]
 }
 
 func NewGraph[ID comparable]() *Graph[ID] {
 	return &Graph[ID]{Lookup: make(map[ID]int)}
 }
 
+func NewUndirectedGraph[ID comparable]() *Graph[ID] {
+	return &Graph[ID]{Lookup: make(map[ID]int), Undirected: true}
+}
+
 func (g *Graph[ID]) AddNode(id ID) {
 	Assert(!g.HasNode(id), "node exists: %v", id)
 
@@ -21,22 +33,38 @@ func (g *Graph[ID]) AddNode(id ID) {
 func (g *Graph[ID]) addNode(id ID) {
 	g.Lookup[id] = len(g.Nodes)
 	g.Nodes = append(g.Nodes, id)
-	g.Edges = append(g.Edges, make(map[int]struct{}))
+	g.Edges = append(g.Edges, make(map[int]int))
+	g.in = append(g.in, NewSet[int]())
 }
 
 func (g *Graph[ID]) AddEdge(a, b ID) {
+	g.AddWeightedEdge(a, b, 1)
+}
+
+func (g *Graph[ID]) AddWeightedEdge(a, b ID, weight int) {
+	Assert(!g.HasEdge(a, b), "edge exists: %v->%v", a, b)
+	g.SetEdge(a, b, weight)
+}
+
+// SetEdge adds the edge, or changes its weight if it exists.
+func (g *Graph[ID]) SetEdge(a, b ID, weight int) {
 	if !g.HasNode(a) {
 		g.addNode(a)
 	}
 	if !g.HasNode(b) {
 		g.addNode(b)
 	}
-	Assert(!g.HasEdge(a, b), "edge exists: %v->%v", a, b)
-	g.addEdge(a, b)
+	g.addEdge(a, b, weight)
 }
 
-func (g *Graph[ID]) addEdge(a, b ID) {
-	g.Edges[g.Lookup[a]][g.Lookup[b]] = struct{}{}
+func (g *Graph[ID]) addEdge(a, b ID, weight int) {
+	i, j := g.Lookup[a], g.Lookup[b]
+	g.Edges[i][j] = weight
+	g.in[j].Add(i)
+	if g.Undirected {
+		g.Edges[j][i] = weight
+		g.in[i].Add(j)
+	}
 }
 
 func (g *Graph[ID]) HasNode(id ID) bool {
@@ -45,12 +73,133 @@ func (g *Graph[ID]) HasNode(id ID) bool {
 }
 
 func (g *Graph[ID]) HasEdge(a, b ID) bool {
-	if !g.HasNode(a) {
+	_, ok := g.Weight(a, b)
+	return ok
+}
+
+func (g *Graph[ID]) Weight(a, b ID) (int, bool) {
+	if !g.HasNode(a) || !g.HasNode(b) {
+		return 0, false
+	}
+	w, ok := g.Edges[g.Lookup[a]][g.Lookup[b]]
+	return w, ok
+}
+
+// RemoveEdge returns false if there was no such edge.
+func (g *Graph[ID]) RemoveEdge(a, b ID) bool {
+	if !g.HasEdge(a, b) {
 		return false
 	}
-	if !g.HasNode(b) {
+	i, j := g.Lookup[a], g.Lookup[b]
+	delete(g.Edges[i], j)
+	g.in[j].Delete(i)
+	if g.Undirected {
+		delete(g.Edges[j], i)
+		g.in[i].Delete(j)
+	}
+	return true
+}
+
+// RemoveNode removes the node and its edges, keeping the other nodes in order.
+// It returns false if there was no such node.
+func (g *Graph[ID]) RemoveNode(id ID) bool {
+	if !g.HasNode(id) {
 		return false
 	}
-	_, ok := g.Edges[g.Lookup[a]][g.Lookup[b]]
-	return ok
+	removed := g.Lookup[id]
+	shift := func(i int) int {
+		if i > removed {
+			return i - 1
+		}
+		return i
+	}
+
+	edges := make([]map[int]int, 0, len(g.Nodes)-1)
+	for i, out := range g.Edges {
+		if i == removed {
+			continue
+		}
+		shifted := make(map[int]int, len(out))
+		for j, w := range out {
+			if j != removed {
+				shifted[shift(j)] = w
+			}
+		}
+		edges = append(edges, shifted)
+	}
+	g.Edges = edges
+
+	g.in = make([]Set[int], len(edges))
+	for i := range g.in {
+		g.in[i] = NewSet[int]()
+	}
+	for i, out := range edges {
+		for j := range out {
+			g.in[j].Add(i)
+		}
+	}
+
+	g.Nodes = append(g.Nodes[:removed], g.Nodes[removed+1:]...)
+	delete(g.Lookup, id)
+	for i, n := range g.Nodes[removed:] {
+		g.Lookup[n] = removed + i
+	}
+	return true
+}
+
+func (g *Graph[ID]) NodeCount() int {
+	return len(g.Nodes)
+}
+
+// EdgeCount counts undirected edges once.
+func (g *Graph[ID]) EdgeCount() int {
+	count := 0
+	for i, out := range g.Edges {
+		for j := range out {
+			if !g.Undirected || i <= j {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// InNeighbors iterates over the nodes with an edge to id, in insertion order.
+func (g *Graph[ID]) InNeighbors(id ID) iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		if !g.HasNode(id) {
+			return
+		}
+		for i := range Sorted(g.in[g.Lookup[id]]) {
+			if !yield(g.Nodes[i]) {
+				return
+			}
+		}
+	}
+}
+
+// OutEdges iterates over the edges leaving id with their weights, in the
+// insertion order of their ends.
+func (g *Graph[ID]) OutEdges(id ID) iter.Seq2[ID, int] {
+	return func(yield func(ID, int) bool) {
+		if !g.HasNode(id) {
+			return
+		}
+		i := g.Lookup[id]
+		for _, j := range g.successors(i) {
+			if !yield(g.Nodes[j], g.Edges[i][j]) {
+				return
+			}
+		}
+	}
+}
+
+// WeightedNeighbors follows the edges, adding their weights to the cost. As a
+// method value, it is the neighbors function of Dijkstra and the other searches.
+func (g *Graph[ID]) WeightedNeighbors(n WithCost[ID, int]) []WithCost[ID, int] {
+	neighbors := make([]WithCost[ID, int], 0)
+	for to, w := range g.OutEdges(n.Value) {
+		neighbors = append(neighbors, WithCost[ID, int]{Value: to, Cost: n.Cost + w})
+	}
+	return neighbors
 }
@@ -0,0 +1,248 @@
+package utils
+
+import (
+	"iter"
+	"slices"
+)
+
+// Neighbors iterates over the nodes at the end of the edges leaving id, in
+// insertion order.
+func (g *Graph[ID]) Neighbors(id ID) iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		if !g.HasNode(id) {
+			return
+		}
+		for _, j := range g.successors(g.Lookup[id]) {
+			if !yield(g.Nodes[j]) {
+				return
+			}
+		}
+	}
+}
+
+func (g *Graph[ID]) OutDegree(id ID) int {
+	if !g.HasNode(id) {
+		return 0
+	}
+	return len(g.Edges[g.Lookup[id]])
+}
+
+func (g *Graph[ID]) InDegree(id ID) int {
+	if !g.HasNode(id) {
+		return 0
+	}
+	return len(g.in[g.Lookup[id]])
+}
+
+// Degree counts the nodes linked to id in either direction.
+func (g *Graph[ID]) Degree(id ID) int {
+	if !g.HasNode(id) {
+		return 0
+	}
+	i := g.Lookup[id]
+	degree := 0
+	for j := range g.Edges[i] {
+		if j != i {
+			degree++
+		}
+	}
+	for j := range g.in[i] {
+		if _, out := g.Edges[i][j]; !out && j != i {
+			degree++
+		}
+	}
+	return degree
+}
+
+// undirected returns the adjacency of the graph ignoring edge directions and
+// self loops.
+func (g *Graph[ID]) undirected() []Set[int] {
+	adjacency := make([]Set[int], len(g.Nodes))
+	for i := range adjacency {
+		adjacency[i] = NewSet[int]()
+	}
+	for i, edges := range g.Edges {
+		for j := range edges {
+			if i != j {
+				adjacency[i].Add(j)
+				adjacency[j].Add(i)
+			}
+		}
+	}
+	return adjacency
+}
+
+// StronglyConnectedComponents groups the nodes reaching each other, with
+// Tarjan's algorithm. Components come in reverse topological order: each one
+// after the components it has edges to.
+func (g *Graph[ID]) StronglyConnectedComponents() [][]ID {
+	index := make([]int, len(g.Nodes))
+	for i := range index {
+		index[i] = -1
+	}
+	lowLink := make([]int, len(g.Nodes))
+	onStack := make([]bool, len(g.Nodes))
+	stack := make([]int, 0)
+	next := 0
+	components := make([][]ID, 0)
+
+	var connect func(i int)
+	connect = func(i int) {
+		index[i] = next
+		lowLink[i] = next
+		next++
+		stack = append(stack, i)
+		onStack[i] = true
+
+		for _, j := range g.successors(i) {
+			if index[j] < 0 {
+				connect(j)
+				lowLink[i] = min(lowLink[i], lowLink[j])
+			} else if onStack[j] {
+				lowLink[i] = min(lowLink[i], index[j])
+			}
+		}
+
+		if lowLink[i] != index[i] {
+			return
+		}
+		component := make([]int, 0)
+		for {
+			j := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[j] = false
+			component = append(component, j)
+			if j == i {
+				break
+			}
+		}
+		slices.Sort(component)
+		components = append(components, g.ids(component))
+	}
+
+	for i := range g.Nodes {
+		if index[i] < 0 {
+			connect(i)
+		}
+	}
+	return components
+}
+
+// ConnectedComponents groups the nodes linked by edges in either direction.
+// Components and their nodes follow the insertion order.
+func (g *Graph[ID]) ConnectedComponents() [][]ID {
+	adjacency := g.undirected()
+	component := make([]int, len(g.Nodes))
+	for i := range component {
+		component[i] = -1
+	}
+
+	components := make([][]ID, 0)
+	for i := range g.Nodes {
+		if component[i] >= 0 {
+			continue
+		}
+		c := len(components)
+		members := []int{i}
+		component[i] = c
+		for k := 0; k < len(members); k++ {
+			for j := range adjacency[members[k]] {
+				if component[j] < 0 {
+					component[j] = c
+					members = append(members, j)
+				}
+			}
+		}
+		slices.Sort(members)
+		components = append(components, g.ids(members))
+	}
+	return components
+}
+
+// MaximalCliques iterates over the sets of nodes all linked together, in either
+// direction, that no other node could join. It is Bron–Kerbosch with pivoting.
+func (g *Graph[ID]) MaximalCliques() iter.Seq[[]ID] {
+	return func(yield func([]ID) bool) {
+		adjacency := g.undirected()
+
+		var expand func(r []int, p, x Set[int]) bool
+		expand = func(r []int, p, x Set[int]) bool {
+			if len(p) == 0 && len(x) == 0 {
+				clique := slices.Clone(r)
+				slices.Sort(clique)
+				return yield(g.ids(clique))
+			}
+
+			// Any maximal clique holds the pivot or one of its non-neighbors.
+			pivot, best := -1, -1
+			for _, candidates := range []Set[int]{p, x} {
+				for u := range candidates {
+					links := 0
+					for v := range p {
+						if adjacency[u].Exists(v) {
+							links++
+						}
+					}
+					if links > best {
+						pivot, best = u, links
+					}
+				}
+			}
+
+			vertices := make([]int, 0, len(p))
+			for v := range p {
+				if !adjacency[pivot].Exists(v) {
+					vertices = append(vertices, v)
+				}
+			}
+			slices.Sort(vertices)
+
+			for _, v := range vertices {
+				nextP, nextX := NewSet[int](), NewSet[int]()
+				for u := range adjacency[v] {
+					if p.Exists(u) {
+						nextP.Add(u)
+					}
+					if x.Exists(u) {
+						nextX.Add(u)
+					}
+				}
+				if !expand(append(r, v), nextP, nextX) {
+					return false
+				}
+				p.Delete(v)
+				x.Add(v)
+			}
+			return true
+		}
+
+		all := NewSet[int]()
+		for i := range g.Nodes {
+			all.Add(i)
+		}
+		expand(make([]int, 0), all, NewSet[int]())
+	}
+}
+
+// Triangles iterates over the triples of nodes linked together, in either
+// direction. Each triangle comes once, its nodes in insertion order.
+func (g *Graph[ID]) Triangles() iter.Seq[[3]ID] {
+	return func(yield func([3]ID) bool) {
+		adjacency := g.undirected()
+		for a := range g.Nodes {
+			for b := range adjacency[a] {
+				if b <= a {
+					continue
+				}
+				for c := range adjacency[b] {
+					if c <= b || !adjacency[a].Exists(c) {
+						continue
+					}
+					if !yield([3]ID{g.Nodes[a], g.Nodes[b], g.Nodes[c]}) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
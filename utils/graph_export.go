@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GraphExporter writes a graph in Graphviz DOT or Mermaid syntax.
+type GraphExporter interface {
+	DOT() string
+	Mermaid() string
+}
+
+// GraphBuilt is sent by days having a graph worth looking at, for the --graph
+// flag of the cli. Build is only called by listeners exporting the graph, so
+// that normal runs do not pay for it.
+type GraphBuilt struct {
+	Name  string
+	Build func() GraphExporter
+}
+
+// LabeledGraph exports a graph. Nodes are shown with Label, fmt.Sprint if nil,
+// and the Highlight nodes, with the edges between them, stand out.
+type LabeledGraph[ID comparable] struct {
+	Graph     *Graph[ID]
+	Label     func(ID) string
+	Highlight Set[ID]
+}
+
+// ParentsGraph turns the parents of DijkstraAll into a graph with edges from
+// parents to children. Nodes are sorted with cmp, for exports to be stable.
+func ParentsGraph[T comparable](parents map[T]Set[T], cmp func(a, b T) int) *Graph[T] {
+	nodes := NewSet[T]()
+	for child, ps := range parents {
+		if len(ps) > 0 {
+			nodes.Add(child)
+			nodes.Union(ps)
+		}
+	}
+
+	g := NewGraph[T]()
+	for n := range nodes.SortedFunc(cmp) {
+		g.AddNode(n)
+	}
+	for child, ps := range parents {
+		for p := range ps {
+			g.SetEdge(p, child, 1)
+		}
+	}
+	return g
+}
+
+func (l LabeledGraph[ID]) label(i int) string {
+	id := l.Graph.Nodes[i]
+	if l.Label == nil {
+		return fmt.Sprint(id)
+	}
+	return l.Label(id)
+}
+
+func (l LabeledGraph[ID]) highlighted(i int) bool {
+	return l.Highlight.Exists(l.Graph.Nodes[i])
+}
+
+// edges calls f once per edge, once per pair of nodes for undirected graphs.
+func (l LabeledGraph[ID]) edges(f func(from, to, weight int)) {
+	for i := range l.Graph.Nodes {
+		for _, j := range l.Graph.successors(i) {
+			if !l.Graph.Undirected || i <= j {
+				f(i, j, l.Graph.Edges[i][j])
+			}
+		}
+	}
+}
+
+func (l LabeledGraph[ID]) DOT() string {
+	kind, arrow := "digraph", "->"
+	if l.Graph.Undirected {
+		kind, arrow = "graph", "--"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s {\n", kind)
+	for i := range l.Graph.Nodes {
+		fmt.Fprintf(&sb, "  n%d [label=%q", i, l.label(i))
+		if l.highlighted(i) {
+			sb.WriteString(", style=filled, fillcolor=gold")
+		}
+		sb.WriteString("];\n")
+	}
+	l.edges(func(from, to, weight int) {
+		attrs := make([]string, 0, 2)
+		if weight != 1 {
+			attrs = append(attrs, fmt.Sprintf("label=\"%d\"", weight))
+		}
+		if l.highlighted(from) && l.highlighted(to) {
+			attrs = append(attrs, "color=red, penwidth=2")
+		}
+		fmt.Fprintf(&sb, "  n%d %s n%d", from, arrow, to)
+		if len(attrs) > 0 {
+			fmt.Fprintf(&sb, " [%s]", strings.Join(attrs, ", "))
+		}
+		sb.WriteString(";\n")
+	})
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func (l LabeledGraph[ID]) Mermaid() string {
+	arrow := "-->"
+	if l.Graph.Undirected {
+		arrow = "---"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	highlighted := make([]string, 0)
+	for i := range l.Graph.Nodes {
+		// Mermaid has no escaping in quoted labels, only html entities.
+		fmt.Fprintf(&sb, "  n%d[\"%s\"]\n", i, strings.ReplaceAll(l.label(i), `"`, "#quot;"))
+		if l.highlighted(i) {
+			highlighted = append(highlighted, fmt.Sprintf("n%d", i))
+		}
+	}
+	l.edges(func(from, to, weight int) {
+		if weight != 1 {
+			fmt.Fprintf(&sb, "  n%d %s|%d| n%d\n", from, arrow, weight, to)
+		} else {
+			fmt.Fprintf(&sb, "  n%d %s n%d\n", from, arrow, to)
+		}
+	})
+	if len(highlighted) > 0 {
+		sb.WriteString("  classDef path fill:#ffd700,stroke:#f00\n")
+		fmt.Fprintf(&sb, "  class %s path\n", strings.Join(highlighted, ","))
+	}
+	return sb.String()
+}
@@ -0,0 +1,61 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func TestGraphExport(t *testing.T) {
+	is := is.New(t)
+
+	g := graphOf([2]string{"a", "b"}, [2]string{"b", "c"})
+	g.AddWeightedEdge("a", "c", 5)
+	highlight := utils.NewSet[string]()
+	highlight.Add("a")
+	highlight.Add("b")
+	l := utils.LabeledGraph[string]{Graph: g, Highlight: highlight, Label: func(id string) string { return `"` + id + `"` }}
+
+	is.Equal(l.DOT(), `digraph {
+  n0 [label="\"a\"", style=filled, fillcolor=gold];
+  n1 [label="\"b\"", style=filled, fillcolor=gold];
+  n2 [label="\"c\""];
+  n0 -> n1 [color=red, penwidth=2];
+  n0 -> n2 [label="5"];
+  n1 -> n2;
+}
+`)
+	is.Equal(l.Mermaid(), `graph LR
+  n0["#quot;a#quot;"]
+  n1["#quot;b#quot;"]
+  n2["#quot;c#quot;"]
+  n0 --> n1
+  n0 -->|5| n2
+  n1 --> n2
+  classDef path fill:#ffd700,stroke:#f00
+  class n0,n1 path
+`)
+
+	u := utils.NewUndirectedGraph[int]()
+	u.AddEdge(1, 2)
+	is.Equal(utils.LabeledGraph[int]{Graph: u}.DOT(), `graph {
+  n0 [label="1"];
+  n1 [label="2"];
+  n0 -- n1;
+}
+`)
+}
+
+func TestParentsGraph(t *testing.T) {
+	is := is.New(t)
+
+	g := utils.ParentsGraph(diamond(), strings.Compare)
+	is.Equal(g.NodeCount(), 5)
+	is.Equal(g.EdgeCount(), 5)
+	is.True(g.HasEdge("a", "b"))
+	is.True(g.HasEdge("c", "d"))
+	is.True(!g.HasEdge("d", "c"))
+	is.Equal(g.Nodes, []string{"a", "b", "c", "d", "e"})
+}
@@ -0,0 +1,193 @@
+package utils
+
+import "slices"
+
+// successors returns the indices of the nodes after i, sorted so that the
+// algorithms below follow the insertion order of the nodes.
+func (g *Graph[ID]) successors(i int) []int {
+	next := make([]int, 0, len(g.Edges[i]))
+	for j := range g.Edges[i] {
+		next = append(next, j)
+	}
+	slices.Sort(next)
+	return next
+}
+
+func (g *Graph[ID]) ids(indices []int) []ID {
+	return MapTo(indices, func(i int) ID { return g.Nodes[i] })
+}
+
+// TopologicalSort orders the nodes so that every edge goes forward, with Kahn's
+// algorithm. Among the possible orders, it returns the one keeping the nodes the
+// closest to their insertion order. It fails if the graph has a cycle.
+func (g *Graph[ID]) TopologicalSort() ([]ID, bool) {
+	inDegree := make([]int, len(g.Nodes))
+	for _, edges := range g.Edges {
+		for j := range edges {
+			inDegree[j]++
+		}
+	}
+
+	ready := NewPriorityQueue[int, int]()
+	for i, d := range inDegree {
+		if d == 0 {
+			ready.Push(i, i)
+		}
+	}
+
+	order := make([]int, 0, len(g.Nodes))
+	for !ready.IsEmpty() {
+		i := ready.Pop().Value
+		order = append(order, i)
+		for j := range g.Edges[i] {
+			inDegree[j]--
+			if inDegree[j] == 0 {
+				ready.Push(j, j)
+			}
+		}
+	}
+
+	if len(order) != len(g.Nodes) {
+		return nil, false
+	}
+	return g.ids(order), true
+}
+
+// TopologicalSortDFS orders the nodes so that every edge goes forward, by
+// reversing the depth-first post-order. It fails if the graph has a cycle.
+func (g *Graph[ID]) TopologicalSortDFS() ([]ID, bool) {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make([]int, len(g.Nodes))
+	order := make([]int, 0, len(g.Nodes))
+
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		switch state[i] {
+		case inProgress:
+			return false
+		case done:
+			return true
+		}
+		state[i] = inProgress
+		for _, j := range g.successors(i) {
+			if !visit(j) {
+				return false
+			}
+		}
+		state[i] = done
+		order = append(order, i)
+		return true
+	}
+
+	for i := range g.Nodes {
+		if !visit(i) {
+			return nil, false
+		}
+	}
+	slices.Reverse(order)
+	return g.ids(order), true
+}
+
+// FindCycle returns the nodes of a cycle, in the order of its edges, the last
+// node going back to the first one.
+func (g *Graph[ID]) FindCycle() ([]ID, bool) {
+	onStack := make([]bool, len(g.Nodes))
+	visited := make([]bool, len(g.Nodes))
+	stack := make([]int, 0)
+
+	var visit func(i int) []int
+	visit = func(i int) []int {
+		visited[i] = true
+		onStack[i] = true
+		stack = append(stack, i)
+		for _, j := range g.successors(i) {
+			if onStack[j] {
+				return slices.Clone(stack[slices.Index(stack, j):])
+			}
+			if visited[j] {
+				continue
+			}
+			if cycle := visit(j); cycle != nil {
+				return cycle
+			}
+		}
+		onStack[i] = false
+		stack = stack[:len(stack)-1]
+		return nil
+	}
+
+	for i := range g.Nodes {
+		if visited[i] {
+			continue
+		}
+		if cycle := visit(i); cycle != nil {
+			return g.ids(cycle), true
+		}
+	}
+	return nil, false
+}
+
+// Reachable returns the nodes at the end of a path of at least one edge from
+// the given node. The node itself is in only if it is on a cycle.
+func (g *Graph[ID]) Reachable(from ID) Set[ID] {
+	reached := NewSet[ID]()
+	if !g.HasNode(from) {
+		return reached
+	}
+
+	stack := []int{g.Lookup[from]}
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for j := range g.Edges[i] {
+			if !reached.Exists(g.Nodes[j]) {
+				reached.Add(g.Nodes[j])
+				stack = append(stack, j)
+			}
+		}
+	}
+	return reached
+}
+
+func (g *Graph[ID]) CanReach(from, to ID) bool {
+	return g.Reachable(from).Exists(to)
+}
+
+// TransitiveClosure has an edge a->b for each path from a to b, of weight 1.
+func (g *Graph[ID]) TransitiveClosure() *Graph[ID] {
+	closure := NewGraph[ID]()
+	closure.Undirected = g.Undirected
+	for _, id := range g.Nodes {
+		closure.AddNode(id)
+	}
+	for _, id := range g.Nodes {
+		for other := range g.Reachable(id) {
+			closure.addEdge(id, other, 1)
+		}
+	}
+	return closure
+}
+
+// Subgraph keeps the given nodes, in that order, and the edges between them.
+// Nodes not in the graph are ignored.
+func (g *Graph[ID]) Subgraph(ids ...ID) *Graph[ID] {
+	sub := NewGraph[ID]()
+	sub.Undirected = g.Undirected
+	for _, id := range ids {
+		if g.HasNode(id) && !sub.HasNode(id) {
+			sub.AddNode(id)
+		}
+	}
+	for _, id := range sub.Nodes {
+		for j, w := range g.Edges[g.Lookup[id]] {
+			if other := g.Nodes[j]; sub.HasNode(other) {
+				sub.addEdge(id, other, w)
+			}
+		}
+	}
+	return sub
+}
@@ -0,0 +1,202 @@
+package utils_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func graphOf(edges ...[2]string) *utils.Graph[string] {
+	g := utils.NewGraph[string]()
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1])
+	}
+	return g
+}
+
+func TestTopologicalSort(t *testing.T) {
+	is := is.New(t)
+
+	g := graphOf([2]string{"c", "a"}, [2]string{"a", "b"}, [2]string{"d", "b"}, [2]string{"c", "d"})
+
+	order, ok := g.TopologicalSort()
+	is.True(ok)
+	is.Equal(order, []string{"c", "a", "d", "b"})
+
+	order, ok = g.TopologicalSortDFS()
+	is.True(ok)
+	position := func(id string) int { return slices.Index(order, id) }
+	is.Equal(len(order), 4)
+	for _, e := range [][2]string{{"c", "a"}, {"a", "b"}, {"d", "b"}, {"c", "d"}} {
+		is.True(position(e[0]) < position(e[1]))
+	}
+
+	_, ok = g.FindCycle()
+	is.True(!ok)
+
+	g.AddEdge("b", "c")
+	_, ok = g.TopologicalSort()
+	is.True(!ok)
+	_, ok = g.TopologicalSortDFS()
+	is.True(!ok)
+
+	cycle, ok := g.FindCycle()
+	is.True(ok)
+	for i, id := range cycle {
+		is.True(g.HasEdge(id, cycle[(i+1)%len(cycle)]))
+	}
+}
+
+func TestReachability(t *testing.T) {
+	is := is.New(t)
+
+	g := graphOf([2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"d", "a"})
+	g.AddNode("e")
+
+	is.Equal(g.Reachable("a").String(), "{b,c}")
+	is.Equal(g.Reachable("e").String(), "{}")
+	is.True(g.CanReach("d", "c"))
+	is.True(!g.CanReach("c", "d"))
+
+	closure := g.TransitiveClosure()
+	is.True(closure.HasEdge("d", "c"))
+	is.True(closure.HasEdge("a", "c"))
+	is.True(!closure.HasEdge("a", "a"))
+	is.True(closure.HasNode("e"))
+
+	sub := g.Subgraph("c", "a", "d", "z")
+	is.Equal(sub.Nodes, []string{"c", "a", "d"})
+	is.True(sub.HasEdge("d", "a"))
+	is.True(!sub.HasEdge("a", "c"))
+}
+
+func TestGraphComponents(t *testing.T) {
+	is := is.New(t)
+
+	g := graphOf(
+		[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"},
+		[2]string{"c", "d"}, [2]string{"d", "e"}, [2]string{"e", "d"},
+		[2]string{"x", "y"},
+	)
+
+	is.Equal(g.StronglyConnectedComponents(), [][]string{{"d", "e"}, {"a", "b", "c"}, {"y"}, {"x"}})
+	is.Equal(g.ConnectedComponents(), [][]string{{"a", "b", "c", "d", "e"}, {"x", "y"}})
+
+	is.Equal(slices.Collect(g.Neighbors("c")), []string{"a", "d"})
+	is.Equal(g.OutDegree("c"), 2)
+	is.Equal(g.InDegree("d"), 2)
+	is.Equal(g.Degree("d"), 2)
+	is.Equal(g.Degree("a"), 2)
+}
+
+func TestCliques(t *testing.T) {
+	is := is.New(t)
+
+	// The LAN party sample: ka, co, de and ta are all connected.
+	g := graphOf(
+		[2]string{"ka", "co"}, [2]string{"ta", "co"}, [2]string{"de", "co"},
+		[2]string{"ta", "ka"}, [2]string{"de", "ta"}, [2]string{"ka", "de"},
+		[2]string{"co", "tc"}, [2]string{"tc", "ka"}, [2]string{"wh", "tc"},
+	)
+
+	largest := []string{}
+	count := 0
+	for c := range g.MaximalCliques() {
+		count++
+		if len(c) > len(largest) {
+			largest = c
+		}
+	}
+	is.Equal(largest, []string{"ka", "co", "ta", "de"})
+	is.Equal(count, 3)
+
+	triangles := 0
+	for tri := range g.Triangles() {
+		triangles++
+		is.True(g.HasEdge(tri[0], tri[1]) || g.HasEdge(tri[1], tri[0]))
+	}
+	is.Equal(triangles, 5)
+}
+
+func TestGraphEdits(t *testing.T) {
+	is := is.New(t)
+
+	g := graphOf([2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"}, [2]string{"a", "d"})
+	is.Equal(g.NodeCount(), 4)
+	is.Equal(g.EdgeCount(), 4)
+	is.Equal(slices.Collect(g.InNeighbors("a")), []string{"c"})
+
+	is.True(g.RemoveEdge("c", "a"))
+	is.True(!g.RemoveEdge("c", "a"))
+	is.Equal(g.EdgeCount(), 3)
+	is.Equal(g.InDegree("a"), 0)
+	is.Equal(g.Degree("a"), 2)
+
+	is.True(g.RemoveNode("b"))
+	is.True(!g.RemoveNode("b"))
+	is.Equal(g.Nodes, []string{"a", "c", "d"})
+	is.Equal(g.EdgeCount(), 1)
+	is.True(g.HasEdge("a", "d"))
+	is.True(!g.HasNode("b"))
+	is.Equal(g.Lookup["d"], 2)
+	is.Equal(g.InDegree("d"), 1)
+	is.Equal(g.Degree("c"), 0)
+	is.Equal(slices.Collect(g.InNeighbors("d")), []string{"a"})
+}
+
+func TestWeightedGraph(t *testing.T) {
+	is := is.New(t)
+
+	g := utils.NewUndirectedGraph[string]()
+	g.AddWeightedEdge("a", "b", 7)
+	g.AddWeightedEdge("a", "c", 9)
+	g.AddWeightedEdge("a", "f", 14)
+	g.AddWeightedEdge("b", "c", 10)
+	g.AddWeightedEdge("b", "d", 15)
+	g.AddWeightedEdge("c", "d", 11)
+	g.AddWeightedEdge("c", "f", 2)
+	g.AddWeightedEdge("d", "e", 6)
+	g.AddWeightedEdge("e", "f", 9)
+
+	is.Equal(g.EdgeCount(), 9)
+	w, ok := g.Weight("f", "c")
+	is.True(ok)
+	is.Equal(w, 2)
+	g.SetEdge("f", "c", 3)
+	w, _ = g.Weight("c", "f")
+	is.Equal(w, 3)
+
+	isDone := func(id string) bool { return id == "e" }
+	path, cost, ok := utils.Dijkstra(utils.WithCost[string, int]{Value: "a"}, isDone, g.WeightedNeighbors, nil)
+	is.True(ok)
+	is.Equal(cost, 21)
+	is.Equal(path, []string{"a", "c", "f", "e"})
+
+	is.True(g.RemoveEdge("e", "f"))
+	is.True(!g.HasEdge("f", "e"))
+	_, cost, _ = utils.Dijkstra(utils.WithCost[string, int]{Value: "a"}, isDone, g.WeightedNeighbors, nil)
+	is.Equal(cost, 26)
+}
+
+func TestWeightedNeighborsCheapestPath(t *testing.T) {
+	is := is.New(t)
+
+	// The direct edge reaches the goal first, but costs more.
+	g := utils.NewGraph[string]()
+	g.AddWeightedEdge("a", "e", 100)
+	g.AddWeightedEdge("a", "b", 1)
+	g.AddWeightedEdge("b", "e", 1)
+
+	isDone := func(id string) bool { return id == "e" }
+	start := utils.WithCost[string, int]{Value: "a"}
+	path, cost, ok := utils.Dijkstra(start, isDone, g.WeightedNeighbors, nil)
+	is.True(ok)
+	is.Equal(cost, 2)
+	is.Equal(path, []string{"a", "b", "e"})
+
+	astarPath, astarCost, _, _ := utils.AStar(start, isDone, g.WeightedNeighbors, func(string) int { return 0 }, nil)
+	is.Equal(astarCost, cost)
+	is.Equal(astarPath, path)
+}
@@ -17,6 +17,9 @@ type Grid[T any] struct {
 	MinY int
 	MaxX int
 	MaxY int
+
+	// Wrap makes the grid toroidal: coordinates out of bounds wrap around.
+	Wrap bool
 }
 
 func NewGrid[T any](width, height uint) *Grid[T] {
@@ -36,6 +39,25 @@ func NewGridEx[T any](width, height uint, minX, minY int) *Grid[T] {
 	}
 }
 
+// NewWrappingGrid creates a toroidal grid, see Wrap.
+func NewWrappingGrid[T any](width, height uint) *Grid[T] {
+	Assert(width > 0 && height > 0, "empty wrapping grid: %dx%d", width, height)
+	g := NewGrid[T](width, height)
+	g.Wrap = true
+	return g
+}
+
+// CountWrapped counts the positions on each cell of a wrapping grid. Positions
+// can be anywhere, they wrap around.
+func CountWrapped[P any](width, height uint, positions []P, xy func(P) (int, int)) *Grid[int] {
+	g := NewWrappingGrid[int](width, height)
+	for _, p := range positions {
+		x, y := xy(p)
+		g.Set(x, y, g.At(x, y)+1)
+	}
+	return g
+}
+
 func (g *Grid[T]) SetAll(value T) {
 	for i := range g.cells {
 		g.cells[i] = value
@@ -48,10 +70,27 @@ func (g *Grid[T]) SetAllFunc(init func() T) {
 	}
 }
 
+// IsCoordValid tells if the coordinates are within bounds, even for wrapping
+// grids, whose At and Set accept any coordinates.
 func (g Grid[T]) IsCoordValid(x, y int) bool {
 	return x >= g.MinX && x <= g.MaxX && y >= g.MinY && y <= g.MaxY
 }
 
+// Normalize gives the coordinates within bounds of a cell of a wrapping grid.
+// Other grids keep the coordinates unchanged.
+func (g Grid[T]) Normalize(x, y int) (int, int) {
+	if !g.Wrap {
+		return x, y
+	}
+	Assert(g.Width > 0 && g.Height > 0, "empty wrapping grid")
+	return g.MinX + Mod(x-g.MinX, int(g.Width)), g.MinY + Mod(y-g.MinY, int(g.Height))
+}
+
+// Apply moves from (x, y) in direction d, wrapping around if needed.
+func (g Grid[T]) Apply(d Direction, x, y int) (int, int) {
+	return g.Normalize(d.Apply(x, y))
+}
+
 func (g Grid[T]) At(x, y int) T {
 	return g.cells[g.coordsToIdx(x, y)]
 }
@@ -72,6 +111,7 @@ func (g Grid[T]) Count(filter func(Cell[T]) bool) int {
 
 func MapGrid[T any, U any](g Grid[T], mapper func(t T) U) *Grid[U] {
 	dst := NewGridEx[U](g.Width, g.Height, g.MinX, g.MinY)
+	dst.Wrap = g.Wrap
 
 	for i := 0; i < len(g.cells); i++ {
 		dst.cells[i] = mapper(g.cells[i])
@@ -94,7 +134,7 @@ func (g Grid[T]) Stringf(format func(T) string) string {
 	sb.WriteString(fmt.Sprintf("[%dx%d] grid:\n", g.Width, g.Height))
 	for i, v := range g.cells {
 		x := i % int(g.Width)
-		y := i / int(g.Height)
+		y := i / int(g.Width)
 
 		if x == 0 && y > 0 {
 			sb.WriteString("\n")
@@ -122,7 +162,7 @@ func (g Grid[T]) StringDots(filter func(T) bool) string {
 					if x+dx >= int(g.Width) {
 						break
 					}
-					current[dx][dy] = filter(g.At(x+dx, y+dy))
+					current[dx][dy] = filter(g.At(g.MinX+x+dx, g.MinY+y+dy))
 				}
 			}
 			sb.WriteRune(current.Rune())
@@ -133,6 +173,7 @@ func (g Grid[T]) StringDots(filter func(T) bool) string {
 }
 
 func (g Grid[T]) coordsToIdx(x, y int) int {
+	x, y = g.Normalize(x, y)
 	Assert(g.IsCoordValid(x, y), "coord not valid: %d,%d", x, y)
 
 	return (y-g.MinY)*int(g.Width) + (x - g.MinX)
@@ -183,7 +224,7 @@ func (n GridNeighbor[T]) NeighborCells(g Grid[T], x, y int) []Cell[T] {
 	neighbors := make([]Cell[T], 0, len(n.Dirs))
 
 	for _, d := range n.Dirs {
-		nx, ny := x+d.Dx, y+d.Dy
+		nx, ny := g.Apply(d, x, y)
 		if !g.IsCoordValid(nx, ny) {
 			continue
 		}
@@ -210,6 +251,7 @@ func (g Grid[T]) Clone() *Grid[T] {
 		MinY:   g.MinY,
 		MaxX:   g.MaxX,
 		MaxY:   g.MaxY,
+		Wrap:   g.Wrap,
 	}
 }
 
@@ -0,0 +1,63 @@
+package utils
+
+import "iter"
+
+// Ray iterates over the cells from (x, y), included, stepping in direction d
+// until the border of the grid. Rays do not wrap: they stop at the border of
+// wrapping grids too. The direction cannot be zero.
+func (g Grid[T]) Ray(x, y int, d Direction) iter.Seq[Cell[T]] {
+	Assert(d != Direction{}, "ray without direction")
+	return func(yield func(Cell[T]) bool) {
+		for ; g.IsCoordValid(x, y); x, y = d.Apply(x, y) {
+			if !yield(Cell[T]{X: x, Y: y, Value: g.At(x, y)}) {
+				return
+			}
+		}
+	}
+}
+
+// CastUntil returns the first cell of the ray verifying blocking, if any.
+func (g Grid[T]) CastUntil(x, y int, d Direction, blocking func(Cell[T]) bool) (Cell[T], bool) {
+	for c := range g.Ray(x, y, d) {
+		if blocking(c) {
+			return c, true
+		}
+	}
+	return Cell[T]{}, false
+}
+
+// Line iterates over the cells of the Bresenham line from (x1, y1) to
+// (x2, y2), both included. Points out of the grid are skipped.
+func (g Grid[T]) Line(x1, y1, x2, y2 int) iter.Seq[Cell[T]] {
+	return func(yield func(Cell[T]) bool) {
+		dx := Abs(x2 - x1)
+		dy := -Abs(y2 - y1)
+		sx, sy := 1, 1
+		if x1 > x2 {
+			sx = -1
+		}
+		if y1 > y2 {
+			sy = -1
+		}
+
+		err := dx + dy
+		x, y := x1, y1
+		for {
+			if g.IsCoordValid(x, y) && !yield(Cell[T]{X: x, Y: y, Value: g.At(x, y)}) {
+				return
+			}
+			if x == x2 && y == y2 {
+				return
+			}
+			e2 := 2 * err
+			if e2 >= dy {
+				err += dy
+				x += sx
+			}
+			if e2 <= dx {
+				err += dx
+				y += sy
+			}
+		}
+	}
+}
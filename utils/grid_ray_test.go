@@ -0,0 +1,48 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func TestRay(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"abcd",
+		"efgh",
+		"ijkl",
+	)
+
+	is.Equal(runes(g.Ray(1, 0, utils.DirDR)), "bgl")
+	is.Equal(runes(g.Ray(3, 1, utils.DirLeft)), "hgfe")
+	is.Equal(runes(g.Ray(0, 0, utils.Direction{Dx: 2, Dy: 1})), "ag")
+
+	c, ok := g.CastUntil(0, 1, utils.DirRight, func(c utils.Cell[rune]) bool { return c.Value == 'g' })
+	is.True(ok)
+	is.Equal(c, utils.Cell[rune]{X: 2, Y: 1, Value: 'g'})
+
+	_, ok = g.CastUntil(0, 1, utils.DirUp, func(c utils.Cell[rune]) bool { return c.Value == 'g' })
+	is.True(!ok)
+
+	wrapping := g.Clone()
+	wrapping.Wrap = true
+	is.Equal(runes(wrapping.Ray(1, 1, utils.DirRight)), "fgh")
+}
+
+func TestLine(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"abcd",
+		"efgh",
+		"ijkl",
+	)
+
+	is.Equal(runes(g.Line(0, 0, 3, 2)), "afgl")
+	is.Equal(runes(g.Line(3, 2, 0, 0)), "lgfa")
+	is.Equal(runes(g.Line(1, 2, 1, 0)), "jfb")
+	is.Equal(runes(g.Line(-1, 1, 4, 1)), "efgh") // Out of the grid cells are skipped
+}
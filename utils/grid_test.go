@@ -68,3 +68,48 @@ func TestNeighbors(t *testing.T) {
 		{X: 1, Y: 8, Value: false},
 	})
 }
+
+func TestWrappingGrid(t *testing.T) {
+	is := is.New(t)
+
+	g := utils.NewWrappingGrid[int](4, 3)
+	g.Set(-1, 3, 5)
+
+	is.Equal(g.At(3, 0), 5)
+	is.Equal(g.At(7, -3), 5)
+	is.Equal(g.At(-1, 0), 5)
+	is.True(!g.IsCoordValid(100, -100))
+
+	walked := 0
+	for x := 0; g.IsCoordValid(x, 0); x++ {
+		walked++
+	}
+	is.Equal(walked, 4)
+
+	x, y := g.Apply(utils.DirLeft, 0, 0)
+	is.Equal([]int{x, y}, []int{3, 0})
+
+	is.Equal(utils.NewNeighbors4[int]().NeighborCells(*g, 0, 0), []utils.Cell[int]{
+		{X: 0, Y: 2, Value: 0},
+		{X: 1, Y: 0, Value: 0},
+		{X: 0, Y: 1, Value: 0},
+		{X: 3, Y: 0, Value: 5},
+	})
+
+	rows := 0
+	for range g.Rows() {
+		rows++
+	}
+	is.Equal(rows, 3)
+}
+
+func TestCountWrapped(t *testing.T) {
+	is := is.New(t)
+
+	positions := [][2]int{{0, 0}, {4, 3}, {-4, 6}, {1, 1}}
+	g := utils.CountWrapped(4, 3, positions, func(p [2]int) (int, int) { return p[0], p[1] })
+
+	is.Equal(g.At(0, 0), 3)
+	is.Equal(g.At(1, 1), 1)
+	is.Equal(g.Count(func(c utils.Cell[int]) bool { return c.Value > 0 }), 2)
+}
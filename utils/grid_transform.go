@@ -0,0 +1,186 @@
+package utils
+
+import "iter"
+
+// Geometric transformations create new grids with the same origin (MinX, MinY).
+
+// transform copies every cell of g to a new w x h grid, at the local
+// coordinates given by f.
+func transform[T any](g Grid[T], w, h uint, f func(x, y int) (int, int)) *Grid[T] {
+	dst := NewGridEx[T](w, h, g.MinX, g.MinY)
+	dst.Wrap = g.Wrap
+	for c := range g.AllCells() {
+		x, y := f(c.X-g.MinX, c.Y-g.MinY)
+		dst.Set(g.MinX+x, g.MinY+y, c.Value)
+	}
+	return dst
+}
+
+// Rotate90 rotates the grid clockwise.
+func (g Grid[T]) Rotate90() *Grid[T] {
+	return transform(g, g.Height, g.Width, func(x, y int) (int, int) { return int(g.Height) - 1 - y, x })
+}
+
+func (g Grid[T]) Rotate180() *Grid[T] {
+	return transform(g, g.Width, g.Height, func(x, y int) (int, int) { return int(g.Width) - 1 - x, int(g.Height) - 1 - y })
+}
+
+// Rotate270 rotates the grid counterclockwise.
+func (g Grid[T]) Rotate270() *Grid[T] {
+	return transform(g, g.Height, g.Width, func(x, y int) (int, int) { return y, int(g.Width) - 1 - x })
+}
+
+// FlipHorizontal mirrors the grid left to right.
+func (g Grid[T]) FlipHorizontal() *Grid[T] {
+	return transform(g, g.Width, g.Height, func(x, y int) (int, int) { return int(g.Width) - 1 - x, y })
+}
+
+// FlipVertical mirrors the grid top to bottom.
+func (g Grid[T]) FlipVertical() *Grid[T] {
+	return transform(g, g.Width, g.Height, func(x, y int) (int, int) { return x, int(g.Height) - 1 - y })
+}
+
+func (g Grid[T]) Transpose() *Grid[T] {
+	return transform(g, g.Height, g.Width, func(x, y int) (int, int) { return y, x })
+}
+
+// Crop copies the rectangle starting at (x, y). Cells keep their coordinates.
+func (g Grid[T]) Crop(x, y int, width, height uint) *Grid[T] {
+	dst := NewGridEx[T](width, height, x, y)
+	for j := y; j <= dst.MaxY; j++ {
+		for i := x; i <= dst.MaxX; i++ {
+			dst.Set(i, j, g.At(i, j))
+		}
+	}
+	return dst
+}
+
+// SubGrid is a view of the rectangle starting at (x, y), sharing the cells of
+// the grid. Cells keep their coordinates.
+func (g *Grid[T]) SubGrid(x, y int, width, height uint) GridView[T] {
+	v := GridView[T]{
+		grid:   g,
+		Width:  width,
+		Height: height,
+		MinX:   x,
+		MinY:   y,
+		MaxX:   x + int(width) - 1,
+		MaxY:   y + int(height) - 1,
+	}
+	Assert(width == 0 || height == 0 || g.IsCoordValid(v.MinX, v.MinY) && g.IsCoordValid(v.MaxX, v.MaxY),
+		"sub grid out of bounds: %d,%d %dx%d", x, y, width, height)
+	return v
+}
+
+type GridView[T any] struct {
+	grid   *Grid[T]
+	Width  uint
+	Height uint
+
+	MinX int
+	MinY int
+	MaxX int
+	MaxY int
+}
+
+func (v GridView[T]) IsCoordValid(x, y int) bool {
+	return x >= v.MinX && x <= v.MaxX && y >= v.MinY && y <= v.MaxY
+}
+
+func (v GridView[T]) At(x, y int) T {
+	Assert(v.IsCoordValid(x, y), "coord not valid: %d,%d", x, y)
+	return v.grid.At(x, y)
+}
+
+// Set modifies the underlying grid.
+func (v GridView[T]) Set(x, y int, value T) {
+	Assert(v.IsCoordValid(x, y), "coord not valid: %d,%d", x, y)
+	v.grid.Set(x, y, value)
+}
+
+func (v GridView[T]) AllCells() iter.Seq[Cell[T]] {
+	return func(yield func(Cell[T]) bool) {
+		for y := v.MinY; y <= v.MaxY; y++ {
+			for x := v.MinX; x <= v.MaxX; x++ {
+				if !yield(Cell[T]{X: x, Y: y, Value: v.grid.At(x, y)}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Clone copies the view into its own grid.
+func (v GridView[T]) Clone() *Grid[T] {
+	return v.grid.Crop(v.MinX, v.MinY, v.Width, v.Height)
+}
+
+func (v GridView[T]) String() string {
+	return v.Clone().String()
+}
+
+func (v GridView[T]) Stringf(format func(T) string) string {
+	return v.Clone().Stringf(format)
+}
+
+func (g Grid[T]) Row(y int) iter.Seq[Cell[T]] {
+	return g.Ray(g.MinX, y, DirRight)
+}
+
+func (g Grid[T]) Column(x int) iter.Seq[Cell[T]] {
+	return g.Ray(x, g.MinY, DirDown)
+}
+
+func (g Grid[T]) Rows() iter.Seq[iter.Seq[Cell[T]]] {
+	return func(yield func(iter.Seq[Cell[T]]) bool) {
+		for y := g.MinY; y <= g.MaxY; y++ {
+			if !yield(g.Row(y)) {
+				return
+			}
+		}
+	}
+}
+
+func (g Grid[T]) Columns() iter.Seq[iter.Seq[Cell[T]]] {
+	return func(yield func(iter.Seq[Cell[T]]) bool) {
+		for x := g.MinX; x <= g.MaxX; x++ {
+			if !yield(g.Column(x)) {
+				return
+			}
+		}
+	}
+}
+
+// Diagonals iterates over the diagonals going down right, starting from the
+// bottom left corner.
+func (g Grid[T]) Diagonals() iter.Seq[iter.Seq[Cell[T]]] {
+	return func(yield func(iter.Seq[Cell[T]]) bool) {
+		for y := g.MaxY; y > g.MinY; y-- {
+			if !yield(g.Ray(g.MinX, y, DirDR)) {
+				return
+			}
+		}
+		for x := g.MinX; x <= g.MaxX; x++ {
+			if !yield(g.Ray(x, g.MinY, DirDR)) {
+				return
+			}
+		}
+	}
+}
+
+// AntiDiagonals iterates over the diagonals going down left, starting from the
+// top left corner.
+func (g Grid[T]) AntiDiagonals() iter.Seq[iter.Seq[Cell[T]]] {
+	return func(yield func(iter.Seq[Cell[T]]) bool) {
+		for x := g.MinX; x < g.MaxX; x++ {
+			if !yield(g.Ray(x, g.MinY, DirDL)) {
+				return
+			}
+		}
+		for y := g.MinY; y <= g.MaxY; y++ {
+			if !yield(g.Ray(g.MaxX, y, DirDL)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package utils_test
+
+import (
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func runeGrid(lines ...string) *utils.Grid[rune] {
+	g := utils.NewGrid[rune](uint(len(lines[0])), uint(len(lines)))
+	for y, l := range lines {
+		for x, r := range l {
+			g.Set(x, y, r)
+		}
+	}
+	return g
+}
+
+func runeLines(g *utils.Grid[rune]) string {
+	var sb strings.Builder
+	for row := range g.Rows() {
+		sb.WriteString(runes(row))
+		sb.WriteString("|")
+	}
+	return sb.String()
+}
+
+func runes(cells iter.Seq[utils.Cell[rune]]) string {
+	var sb strings.Builder
+	for c := range cells {
+		sb.WriteRune(c.Value)
+	}
+	return sb.String()
+}
+
+func TestGridTransform(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"abc",
+		"def",
+	)
+
+	is.Equal(runeLines(g.Rotate90()), "da|eb|fc|")
+	is.Equal(runeLines(g.Rotate180()), "fed|cba|")
+	is.Equal(runeLines(g.Rotate270()), "cf|be|ad|")
+	is.Equal(runeLines(g.FlipHorizontal()), "cba|fed|")
+	is.Equal(runeLines(g.FlipVertical()), "def|abc|")
+	is.Equal(runeLines(g.Transpose()), "ad|be|cf|")
+
+	crop := g.Crop(1, 0, 2, 2)
+	is.Equal(crop.MinX, 1)
+	is.Equal(runeLines(crop), "bc|ef|")
+}
+
+func TestSubGrid(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"abc",
+		"def",
+		"ghi",
+	)
+
+	v := g.SubGrid(1, 1, 2, 2)
+	is.Equal(v.At(2, 2), 'i')
+	is.True(!v.IsCoordValid(0, 0))
+
+	v.Set(1, 1, 'E') // Writes through to the grid
+	is.Equal(g.At(1, 1), 'E')
+	is.Equal(runeLines(v.Clone()), "Ef|hi|")
+}
+
+func TestGridLines(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"abc",
+		"def",
+	)
+
+	is.Equal(runes(g.Row(1)), "def")
+	is.Equal(runes(g.Column(2)), "cf")
+
+	diagonals := make([]string, 0)
+	for d := range g.Diagonals() {
+		diagonals = append(diagonals, runes(d))
+	}
+	is.Equal(diagonals, []string{"d", "ae", "bf", "c"})
+
+	diagonals = diagonals[:0]
+	for d := range g.AntiDiagonals() {
+		diagonals = append(diagonals, runes(d))
+	}
+	is.Equal(diagonals, []string{"a", "bd", "ce", "f"})
+}
@@ -0,0 +1,263 @@
+package mathx
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+type SolutionKind int
+
+const (
+	NoSolution SolutionKind = iota
+	UniqueSolution
+	InfiniteSolutions
+)
+
+func (k SolutionKind) String() string {
+	switch k {
+	case NoSolution:
+		return "none"
+	case UniqueSolution:
+		return "unique"
+	case InfiniteSolutions:
+		return "infinite"
+	}
+	return "unknown"
+}
+
+// LinearSolution describes the solutions of a linear system, in parametric
+// form: Particular + t1.Directions[0] + t2.Directions[1] + ..., one parameter
+// per free variable. Particular is nil when there is no solution.
+type LinearSolution struct {
+	Kind       SolutionKind
+	Particular []*big.Rat
+	Directions [][]*big.Rat
+	// Free holds the indices of the free variables, Directions[i] setting
+	// Free[i] to 1 and the other free variables to 0.
+	Free []int
+}
+
+// SolveLinear solves a.x = b exactly. The matrix may have any number of rows,
+// all with the same number of columns.
+func SolveLinear(a [][]int, b []int) LinearSolution {
+	ra := make([][]*big.Rat, len(a))
+	for i, row := range a {
+		ra[i] = make([]*big.Rat, len(row))
+		for j, v := range row {
+			ra[i][j] = big.NewRat(int64(v), 1)
+		}
+	}
+	rb := make([]*big.Rat, len(b))
+	for i, v := range b {
+		rb[i] = big.NewRat(int64(v), 1)
+	}
+	return SolveLinearRat(ra, rb)
+}
+
+// SolveLinearRat solves a.x = b by Gauss-Jordan elimination, leaving a and b
+// untouched.
+func SolveLinearRat(a [][]*big.Rat, b []*big.Rat) LinearSolution {
+	if len(a) != len(b) {
+		panic(fmt.Sprintf("SolveLinear: %d rows but %d values", len(a), len(b)))
+	}
+	cols := 0
+	if len(a) > 0 {
+		cols = len(a[0])
+	}
+
+	// Augmented matrix [a | b].
+	m := make([][]*big.Rat, len(a))
+	for i, row := range a {
+		if len(row) != cols {
+			panic(fmt.Sprintf("SolveLinear: row %d has %d columns, not %d", i, len(row), cols))
+		}
+		m[i] = make([]*big.Rat, cols+1)
+		for j, v := range row {
+			m[i][j] = new(big.Rat).Set(v)
+		}
+		m[i][cols] = new(big.Rat).Set(b[i])
+	}
+
+	// Reduced row echelon form: pivots[r] is the column of the pivot of row r.
+	pivots := make([]int, 0, cols)
+	tmp := new(big.Rat)
+	for col, row := 0, 0; col < cols && row < len(m); col++ {
+		pivot := -1
+		for r := row; r < len(m); r++ {
+			if m[r][col].Sign() != 0 {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		m[row], m[pivot] = m[pivot], m[row]
+
+		inverse := new(big.Rat).Inv(m[row][col])
+		for j := col; j <= cols; j++ {
+			m[row][j].Mul(m[row][j], inverse)
+		}
+		for r := range m {
+			if r == row || m[r][col].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(m[r][col])
+			for j := col; j <= cols; j++ {
+				m[r][j].Sub(m[r][j], tmp.Mul(factor, m[row][j]))
+			}
+		}
+		pivots = append(pivots, col)
+		row++
+	}
+
+	// A zero row with a non zero value is a contradiction.
+	for r := len(pivots); r < len(m); r++ {
+		if m[r][cols].Sign() != 0 {
+			return LinearSolution{Kind: NoSolution}
+		}
+	}
+
+	solution := LinearSolution{Kind: UniqueSolution, Particular: zeros(cols)}
+	isPivot := make([]bool, cols)
+	for r, col := range pivots {
+		isPivot[col] = true
+		solution.Particular[col].Set(m[r][cols])
+	}
+
+	for f := 0; f < cols; f++ {
+		if isPivot[f] {
+			continue
+		}
+		direction := zeros(cols)
+		direction[f].SetInt64(1)
+		for r, col := range pivots {
+			direction[col].Neg(m[r][f])
+		}
+		solution.Kind = InfiniteSolutions
+		solution.Free = append(solution.Free, f)
+		solution.Directions = append(solution.Directions, direction)
+	}
+
+	return solution
+}
+
+func zeros(n int) []*big.Rat {
+	values := make([]*big.Rat, n)
+	for i := range values {
+		values[i] = new(big.Rat)
+	}
+	return values
+}
+
+// At returns the solution for the given values of the free variables.
+func (s LinearSolution) At(params ...*big.Rat) []*big.Rat {
+	if len(params) != len(s.Directions) {
+		panic(fmt.Sprintf("LinearSolution.At: %d parameters for %d free variables", len(params), len(s.Directions)))
+	}
+	x := make([]*big.Rat, len(s.Particular))
+	tmp := new(big.Rat)
+	for i, v := range s.Particular {
+		x[i] = new(big.Rat).Set(v)
+		for k, d := range s.Directions {
+			x[i].Add(x[i], tmp.Mul(params[k], d[i]))
+		}
+	}
+	return x
+}
+
+// IntegerSolution returns the unique solution when it only has integers that
+// fit in an int. See IntegerSolutions for systems with a free variable.
+func (s LinearSolution) IntegerSolution() ([]int, bool) {
+	if s.Kind != UniqueSolution {
+		return nil, false
+	}
+	return Integers(s.Particular)
+}
+
+// IntegerSolutions describes the integer solutions of a system with at most one
+// free variable: base + k.step for every integer k, step being nil for a unique
+// solution. It fails when there is no integer solution, or when they do not fit
+// in an int. Systems with more free variables are not supported.
+func (s LinearSolution) IntegerSolutions() (base, step []int, ok bool) {
+	switch {
+	case s.Kind == NoSolution:
+		return nil, nil, false
+	case s.Kind == UniqueSolution:
+		base, ok = Integers(s.Particular)
+		return base, nil, ok
+	case len(s.Directions) > 1:
+		panic(fmt.Sprintf("IntegerSolutions: %d free variables, at most 1 supported", len(s.Directions)))
+	}
+
+	// With l the LCM of the denominators, x = (p + t.d) / l with integers p
+	// and d, and t, the free variable, an integer. Each coordinate is integral
+	// when d[i].t = -p[i] mod l: a congruence on t, all combined with CRT.
+	direction := s.Directions[0]
+	l := big.NewInt(1)
+	for _, v := range append(slices.Clone(s.Particular), direction...) {
+		g := new(big.Int).GCD(nil, nil, l, v.Denom())
+		l.Mul(l, new(big.Int).Quo(v.Denom(), g))
+	}
+	scaled := func(v *big.Rat) (int, bool) {
+		n := new(big.Rat).Mul(v, new(big.Rat).SetInt(l))
+		return fitInt(n.Num())
+	}
+
+	modulus, ok := fitInt(l)
+	if !ok {
+		return nil, nil, false
+	}
+	residues := make([]int, 0, len(direction))
+	moduli := make([]int, 0, len(direction))
+	for i := range direction {
+		p, okP := scaled(s.Particular[i])
+		d, okD := scaled(direction[i])
+		if !okP || !okD {
+			return nil, nil, false
+		}
+		g := GCD(d, modulus)
+		if p%g != 0 {
+			return nil, nil, false
+		}
+		m := modulus / g
+		inverse, _ := ModInverse(d/g, m)
+		residues = append(residues, MulMod(-p/g, inverse, m))
+		moduli = append(moduli, m)
+	}
+	r, m, ok := CRT(residues, moduli)
+	if !ok {
+		return nil, nil, false
+	}
+
+	base, ok = Integers(s.At(big.NewRat(int64(r), 1)))
+	if !ok {
+		return nil, nil, false
+	}
+	steps := make([]*big.Rat, len(direction))
+	for i, v := range direction {
+		steps[i] = new(big.Rat).Mul(v, big.NewRat(int64(m), 1))
+	}
+	step, ok = Integers(steps)
+	return base, step, ok
+}
+
+func fitInt(n *big.Int) (int, bool) {
+	if !n.IsInt64() {
+		return 0, false
+	}
+	return int(n.Int64()), true
+}
+
+// Integers converts rationals to ints, failing on fractions and overflows.
+func Integers(values []*big.Rat) ([]int, bool) {
+	ints := make([]int, len(values))
+	for i, v := range values {
+		if !v.IsInt() || !v.Num().IsInt64() {
+			return nil, false
+		}
+		ints[i] = int(v.Num().Int64())
+	}
+	return ints, true
+}
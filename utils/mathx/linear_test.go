@@ -0,0 +1,85 @@
+package mathx_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/gverger/aoc2024/utils/mathx"
+	"github.com/matryer/is"
+)
+
+func TestSolveLinearUnique(t *testing.T) {
+	is := is.New(t)
+
+	s := mathx.SolveLinear([][]int{{2, 1, -1}, {-3, -1, 2}, {-2, 1, 2}}, []int{8, -11, -3})
+	is.Equal(s.Kind, mathx.UniqueSolution)
+	x, ok := s.IntegerSolution()
+	is.True(ok)
+	is.Equal(x, []int{2, 3, -1})
+
+	s = mathx.SolveLinear([][]int{{2, 0}, {0, 3}}, []int{1, 3})
+	is.Equal(s.Kind, mathx.UniqueSolution)
+	is.Equal(s.Particular[0].String(), "1/2")
+	_, ok = s.IntegerSolution()
+	is.True(!ok)
+}
+
+func TestSolveLinearBig(t *testing.T) {
+	is := is.New(t)
+
+	// A machine of day 13, part 2.
+	p := 10000000000000
+	s := mathx.SolveLinear([][]int{{94, 22}, {34, 67}}, []int{p + 8400, p + 5400})
+	is.Equal(s.Kind, mathx.UniqueSolution)
+	_, ok := s.IntegerSolution()
+	is.True(!ok)
+
+	// The 2x2 determinant terms overflow 64 bits with such values.
+	n := 1 << 40
+	s = mathx.SolveLinear([][]int{{n, n - 1}, {n + 1, n}}, []int{3*n + 5*(n-1), 3*(n+1) + 5*n})
+	x, ok := s.IntegerSolution()
+	is.True(ok)
+	is.Equal(x, []int{3, 5})
+}
+
+func TestSolveLinearDegenerate(t *testing.T) {
+	is := is.New(t)
+
+	s := mathx.SolveLinear([][]int{{1, 2}, {2, 4}}, []int{3, 7})
+	is.Equal(s.Kind, mathx.NoSolution)
+	is.True(s.Particular == nil)
+
+	s = mathx.SolveLinear([][]int{{1, 2, 1}, {2, 4, 0}}, []int{4, 6})
+	is.Equal(s.Kind, mathx.InfiniteSolutions)
+	is.Equal(s.Free, []int{1})
+	is.Equal(len(s.Directions), 1)
+	is.Equal(s.Kind.String(), "infinite")
+
+	// Every point of the parametric form solves the system.
+	for _, t := range []int64{-2, 0, 7} {
+		x := s.At(big.NewRat(t, 1))
+		is.Equal(new(big.Rat).Add(new(big.Rat).Add(x[0], new(big.Rat).Mul(big.NewRat(2, 1), x[1])), x[2]).String(), "4/1")
+		is.Equal(new(big.Rat).Add(new(big.Rat).Mul(big.NewRat(2, 1), x[0]), new(big.Rat).Mul(big.NewRat(4, 1), x[1])).String(), "6/1")
+		is.Equal(x[1].String(), big.NewRat(t, 1).String())
+	}
+	_, ok := s.IntegerSolution()
+	is.True(!ok)
+
+	// x = (3, 0, 1) is one of the integer solutions.
+	base, step, ok := s.IntegerSolutions()
+	is.True(ok)
+	is.Equal(base, []int{3, 0, 1})
+	is.Equal(step, []int{-2, 1, 0})
+
+	// x0 = (1 + x1) / 2 is integral for odd values of x1 only.
+	s = mathx.SolveLinear([][]int{{2, -1}}, []int{1})
+	base, step, ok = s.IntegerSolutions()
+	is.True(ok)
+	is.Equal(base, []int{1, 1})
+	is.Equal(step, []int{1, 2})
+
+	s = mathx.SolveLinear([][]int{{2, -2}}, []int{1})
+	is.Equal(s.Kind, mathx.InfiniteSolutions)
+	_, _, ok = s.IntegerSolutions()
+	is.True(!ok)
+}
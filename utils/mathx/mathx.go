@@ -0,0 +1,148 @@
+// Package mathx holds number theory helpers on ints.
+package mathx
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// GCD is always non-negative, and GCD(0, 0) is 0.
+func GCD(a, b int) int {
+	a, b = abs(a), abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func GCDOf(values ...int) int {
+	g := 0
+	for _, v := range values {
+		g = GCD(g, v)
+	}
+	return g
+}
+
+// LCM is always non-negative, and 0 if a or b is.
+func LCM(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return abs(a / GCD(a, b) * b)
+}
+
+func LCMOf(values ...int) int {
+	l := 1
+	for _, v := range values {
+		l = LCM(l, v)
+	}
+	return l
+}
+
+// ExtendedGCD returns g = GCD(a, b) and x, y such that a*x + b*y = g.
+func ExtendedGCD(a, b int) (g, x, y int) {
+	oldR, r := a, b
+	oldX, x := 1, 0
+	oldY, y := 0, 1
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// Mod is the remainder of a by m, in [0, |m|).
+func Mod(a, m int) int {
+	r := a % m
+	if r < 0 {
+		r += abs(m)
+	}
+	return r
+}
+
+// FloorDiv rounds the quotient down, where Go's division rounds towards zero.
+func FloorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// CeilDiv rounds the quotient up.
+func CeilDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) == (b < 0) {
+		q++
+	}
+	return q
+}
+
+// MulMod computes a*b mod m without overflowing, for m > 0.
+func MulMod(a, b, m int) int {
+	hi, lo := bits.Mul64(uint64(Mod(a, m)), uint64(Mod(b, m)))
+	return int(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// addMod computes a+b mod m without overflowing, for a and b in [0, m).
+func addMod(a, b, m int) int {
+	if a >= m-b {
+		return a - (m - b)
+	}
+	return a + b
+}
+
+// ModInverse returns x in [0, m) with a*x = 1 mod m, if a and m are coprime.
+func ModInverse(a, m int) (int, bool) {
+	g, x, _ := ExtendedGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, false
+	}
+	return Mod(x, m), true
+}
+
+// CRT solves x = residues[i] mod moduli[i] for all i, with the Chinese
+// Remainder Theorem generalized to moduli that are not coprime. The solutions
+// are x = r mod m, r in [0, m). It fails when the congruences contradict each
+// other. Moduli must be positive, and their LCM must fit in an int.
+func CRT(residues, moduli []int) (r, m int, ok bool) {
+	if len(residues) != len(moduli) {
+		panic("CRT: as many residues as moduli expected")
+	}
+
+	r, m = 0, 1
+	for i, mi := range moduli {
+		if mi <= 0 {
+			panic(fmt.Sprintf("CRT: modulus %d is not positive", mi))
+		}
+		ri := Mod(residues[i], mi)
+		g := GCD(m, mi)
+		if (ri-r)%g != 0 {
+			return 0, 0, false
+		}
+		// r + m*k = ri mod mi, so (m/g)*k = (ri-r)/g mod mi/g.
+		step := mi / g
+		inverse, _ := ModInverse(m/g, step)
+		k := MulMod((ri-r)/g, inverse, step)
+		if m > math.MaxInt/step {
+			panic(fmt.Sprintf("CRT: the moduli LCM overflows, %d*%d", m, step))
+		}
+		lcm := m * step
+		r = addMod(r, MulMod(m, k, lcm), lcm)
+		m = lcm
+	}
+	return r, m, true
+}
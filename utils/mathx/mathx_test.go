@@ -0,0 +1,82 @@
+package mathx_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils/mathx"
+	"github.com/matryer/is"
+)
+
+func TestGCD(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(mathx.GCD(12, -18), 6)
+	is.Equal(mathx.GCD(0, 5), 5)
+	is.Equal(mathx.GCDOf(12, 18, 8), 2)
+	is.Equal(mathx.LCM(4, 6), 12)
+	is.Equal(mathx.LCMOf(101, 103, 2), 20806)
+
+	for _, c := range [][2]int{{240, 46}, {-7, 3}, {0, 4}, {17, 0}} {
+		g, x, y := mathx.ExtendedGCD(c[0], c[1])
+		is.Equal(g, mathx.GCD(c[0], c[1]))
+		is.Equal(c[0]*x+c[1]*y, g)
+	}
+}
+
+func TestDivision(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(mathx.FloorDiv(7, 2), 3)
+	is.Equal(mathx.FloorDiv(-7, 2), -4)
+	is.Equal(mathx.FloorDiv(7, -2), -4)
+	is.Equal(mathx.FloorDiv(-8, 2), -4)
+	is.Equal(mathx.CeilDiv(7, 2), 4)
+	is.Equal(mathx.CeilDiv(-7, 2), -3)
+	is.Equal(mathx.CeilDiv(-7, -2), 4)
+	is.Equal(mathx.Mod(-7, 3), 2)
+	is.Equal(mathx.MulMod(1<<62, 1<<62, 1_000_000_007), 829977023)
+}
+
+func TestModInverse(t *testing.T) {
+	is := is.New(t)
+
+	x, ok := mathx.ModInverse(3, 11)
+	is.True(ok)
+	is.Equal(x, 4)
+
+	_, ok = mathx.ModInverse(4, 10)
+	is.True(!ok)
+}
+
+func TestCRT(t *testing.T) {
+	is := is.New(t)
+
+	r, m, ok := mathx.CRT([]int{2, 3, 2}, []int{3, 5, 7})
+	is.True(ok)
+	is.Equal(r, 23)
+	is.Equal(m, 105)
+
+	// Moduli that are not coprime.
+	r, m, ok = mathx.CRT([]int{3, 5}, []int{4, 6})
+	is.True(ok)
+	is.Equal(r, 11)
+	is.Equal(m, 12)
+
+	_, _, ok = mathx.CRT([]int{1, 2}, []int{4, 6})
+	is.True(!ok)
+
+	// A robot tree, seen every 101 turns on x and 103 turns on y.
+	r, m, ok = mathx.CRT([]int{-30, 40}, []int{101, 103})
+	is.True(ok)
+	is.Equal(m, 10403)
+	is.Equal(r%101, 71)
+	is.Equal(r%103, 40)
+
+	// Near the int limit, r + m*k would overflow.
+	n := 1 << 61
+	r, m, ok = mathx.CRT([]int{n - 2, 1}, []int{n - 1, 3})
+	is.True(ok)
+	is.Equal(m, 3*(n-1))
+	is.Equal(r%(n-1), n-2)
+	is.Equal(r%3, 1)
+}
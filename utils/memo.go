@@ -0,0 +1,93 @@
+package utils
+
+import "sync"
+
+type MemoStats struct {
+	Hits   int
+	Misses int
+	Size   int
+}
+
+func (s MemoStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// MemoStatsUpdated is sent by days to show how well their caches do.
+type MemoStatsUpdated struct {
+	Name  string
+	Stats MemoStats
+}
+
+// Memo caches the results of a recursive function. The function receives the
+// memoized version of itself to recurse through the cache.
+type Memo[K comparable, V any] struct {
+	f      func(recurse func(K) V, key K) V
+	cache  map[K]V
+	hits   int
+	misses int
+
+	// mu is only set for concurrent memos.
+	mu *sync.Mutex
+}
+
+func NewMemo[K comparable, V any](f func(recurse func(K) V, key K) V) *Memo[K, V] {
+	return &Memo[K, V]{f: f, cache: make(map[K]V)}
+}
+
+// NewConcurrentMemo can be called from several goroutines. The cache is not
+// locked during computations, so parallel calls may compute the same key twice.
+func NewConcurrentMemo[K comparable, V any](f func(recurse func(K) V, key K) V) *Memo[K, V] {
+	m := NewMemo(f)
+	m.mu = &sync.Mutex{}
+	return m
+}
+
+func (m *Memo[K, V]) lock() {
+	if m.mu != nil {
+		m.mu.Lock()
+	}
+}
+
+func (m *Memo[K, V]) unlock() {
+	if m.mu != nil {
+		m.mu.Unlock()
+	}
+}
+
+func (m *Memo[K, V]) Get(key K) V {
+	m.lock()
+	v, ok := m.cache[key]
+	if ok {
+		m.hits++
+	} else {
+		m.misses++
+	}
+	m.unlock()
+	if ok {
+		return v
+	}
+
+	v = m.f(m.Get, key)
+
+	m.lock()
+	m.cache[key] = v
+	m.unlock()
+	return v
+}
+
+func (m *Memo[K, V]) Stats() MemoStats {
+	m.lock()
+	defer m.unlock()
+	return MemoStats{Hits: m.hits, Misses: m.misses, Size: len(m.cache)}
+}
+
+// Reset empties the cache and the stats.
+func (m *Memo[K, V]) Reset() {
+	m.lock()
+	defer m.unlock()
+	m.cache = make(map[K]V)
+	m.hits, m.misses = 0, 0
+}
@@ -0,0 +1,56 @@
+package utils_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func fibonacci(fib func(int) int, n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
+
+func TestMemo(t *testing.T) {
+	is := is.New(t)
+
+	m := utils.NewMemo(fibonacci)
+	is.Equal(m.Get(90), 2880067194370816120)
+
+	stats := m.Stats()
+	is.Equal(stats.Misses, 91)
+	is.Equal(stats.Size, 91)
+	is.Equal(stats.Hits, 88)
+
+	m.Get(50)
+	is.Equal(m.Stats().Hits, 89)
+	is.True(m.Stats().HitRate() > 0.49)
+
+	m.Reset()
+	is.Equal(m.Stats(), utils.MemoStats{})
+}
+
+func TestConcurrentMemo(t *testing.T) {
+	is := is.New(t)
+
+	m := utils.NewConcurrentMemo(fibonacci)
+	var wg sync.WaitGroup
+	results := make([]int, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = m.Get(60 + i)
+		}()
+	}
+	wg.Wait()
+
+	for i, r := range results {
+		is.Equal(r, utils.NewMemo(fibonacci).Get(60+i))
+	}
+	is.Equal(m.Stats().Size, 68)
+}
@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"cmp"
+	"iter"
+	"math/big"
+	"slices"
+)
+
+// The parents DAG, as returned by DijkstraAll, maps each node to the previous
+// nodes on its optimal paths. Nodes without parents are sources.
+
+// AllPaths iterates over the optimal paths from a source to each end, sources
+// first. Paths are yielded in no particular order.
+func AllPaths[T comparable](parents map[T]Set[T], ends ...T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		reversed := make([]T, 0)
+		var walk func(node T) bool
+		walk = func(node T) bool {
+			reversed = append(reversed, node)
+			defer func() { reversed = reversed[:len(reversed)-1] }()
+
+			if len(parents[node]) == 0 {
+				path := slices.Clone(reversed)
+				slices.Reverse(path)
+				return yield(path)
+			}
+			for p := range parents[node] {
+				if !walk(p) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for _, end := range ends {
+			if !walk(end) {
+				return
+			}
+		}
+	}
+}
+
+// CountPaths counts the optimal paths to the ends without enumerating them.
+func CountPaths[T comparable](parents map[T]Set[T], ends ...T) *big.Int {
+	counts := make(map[T]*big.Int)
+	var count func(node T) *big.Int
+	count = func(node T) *big.Int {
+		if c, ok := counts[node]; ok {
+			return c
+		}
+		c := big.NewInt(0)
+		if len(parents[node]) == 0 {
+			c.SetInt64(1)
+		}
+		for p := range parents[node] {
+			c.Add(c, count(p))
+		}
+		counts[node] = c
+		return c
+	}
+
+	total := big.NewInt(0)
+	for _, end := range ends {
+		total.Add(total, count(end))
+	}
+	return total
+}
+
+// PathNodes returns the nodes lying on at least one optimal path to the ends.
+func PathNodes[T comparable](parents map[T]Set[T], ends ...T) Set[T] {
+	nodes := NewSet[T]()
+	stack := slices.Clone(ends)
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if nodes.Exists(node) {
+			continue
+		}
+		nodes.Add(node)
+		for p := range parents[node] {
+			stack = append(stack, p)
+		}
+	}
+	return nodes
+}
+
+// KShortestPaths returns up to k simple paths from start to a node verifying
+// isDone, cheapest first, using Yen's algorithm. Each path comes with its cost.
+func KShortestPaths[T comparable, U cmp.Ordered](start WithCost[T, U], isDone func(T) bool, neighbors func(WithCost[T, U]) []WithCost[T, U], k int) []WithCost[[]T, U] {
+	noHeuristic := func(T) U { var zero U; return zero }
+
+	best, _, ok := aStar(start, isDone, neighbors, noHeuristic, nil)
+	if !ok || k <= 0 {
+		return nil
+	}
+
+	found := [][]WithCost[T, U]{best}
+	candidates := make([][]WithCost[T, U], 0)
+	sameNodes := func(a, b []WithCost[T, U]) bool {
+		return slices.EqualFunc(a, b, func(x, y WithCost[T, U]) bool { return x.Value == y.Value })
+	}
+	known := func(path []WithCost[T, U]) bool {
+		return slices.ContainsFunc(found, func(p []WithCost[T, U]) bool { return sameNodes(p, path) }) ||
+			slices.ContainsFunc(candidates, func(p []WithCost[T, U]) bool { return sameNodes(p, path) })
+	}
+
+	for len(found) < k {
+		previous := found[len(found)-1]
+		for i := 0; i < len(previous)-1; i++ {
+			spur := previous[i]
+			root := previous[:i+1]
+
+			// The spur path may neither go through the root again, nor leave
+			// the spur node the way an already found path with this root does.
+			removedNodes := NewSet[T]()
+			for _, n := range root[:i] {
+				removedNodes.Add(n.Value)
+			}
+			removedEdges := NewSet[T]()
+			for _, p := range found {
+				if len(p) > i+1 && sameNodes(p[:i+1], root) {
+					removedEdges.Add(p[i+1].Value)
+				}
+			}
+			filtered := func(c WithCost[T, U]) []WithCost[T, U] {
+				next := make([]WithCost[T, U], 0)
+				for _, n := range neighbors(c) {
+					if removedNodes.Exists(n.Value) || c.Value == spur.Value && removedEdges.Exists(n.Value) {
+						continue
+					}
+					next = append(next, n)
+				}
+				return next
+			}
+
+			spurPath, _, ok := aStar(spur, isDone, filtered, noHeuristic, nil)
+			if !ok {
+				continue
+			}
+			path := append(slices.Clone(root[:i]), spurPath...)
+			if !known(path) {
+				candidates = append(candidates, path)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+		cheapest := 0
+		for i, p := range candidates {
+			if p[len(p)-1].Cost < candidates[cheapest][len(candidates[cheapest])-1].Cost {
+				cheapest = i
+			}
+		}
+		found = append(found, candidates[cheapest])
+		candidates = slices.Delete(candidates, cheapest, cheapest+1)
+	}
+
+	return MapTo(found, func(p []WithCost[T, U]) WithCost[[]T, U] {
+		return WithCost[[]T, U]{
+			Value: MapTo(p, func(n WithCost[T, U]) T { return n.Value }),
+			Cost:  p[len(p)-1].Cost,
+		}
+	})
+}
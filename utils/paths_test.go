@@ -0,0 +1,92 @@
+package utils_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+// diamond has two optimal paths from a to d, through b or c.
+func diamond() map[string]utils.Set[string] {
+	set := func(values ...string) utils.Set[string] {
+		s := utils.NewSet[string]()
+		for _, v := range values {
+			s.Add(v)
+		}
+		return s
+	}
+	return map[string]utils.Set[string]{
+		"b": set("a"),
+		"c": set("a"),
+		"d": set("b", "c"),
+		"e": set("d"),
+	}
+}
+
+func TestAllPaths(t *testing.T) {
+	is := is.New(t)
+
+	paths := slices.Collect(utils.AllPaths(diamond(), "e"))
+	slices.SortFunc(paths, slices.Compare)
+	is.Equal(paths, [][]string{{"a", "b", "d", "e"}, {"a", "c", "d", "e"}})
+
+	is.Equal(utils.CountPaths(diamond(), "e").Int64(), int64(2))
+	is.Equal(utils.CountPaths(diamond(), "e", "b").Int64(), int64(3))
+	is.Equal(utils.PathNodes(diamond(), "d").String(), "{a,b,c,d}")
+}
+
+func TestCountPathsBig(t *testing.T) {
+	is := is.New(t)
+
+	// 100 diamonds in a row: 2^100 paths.
+	parents := make(map[int]utils.Set[int])
+	for i := 0; i < 100; i++ {
+		from, left, right, to := 3*i, 3*i+1, 3*i+2, 3*i+3
+		parents[left] = utils.NewSet[int]()
+		parents[left].Add(from)
+		parents[right] = utils.NewSet[int]()
+		parents[right].Add(from)
+		parents[to] = utils.NewSet[int]()
+		parents[to].Add(left)
+		parents[to].Add(right)
+	}
+
+	is.Equal(utils.CountPaths(parents, 300).String(), "1267650600228229401496703205376")
+}
+
+func TestKShortestPaths(t *testing.T) {
+	is := is.New(t)
+
+	// Yen's example graph.
+	edges := map[rune]map[rune]int{
+		'C': {'D': 3, 'E': 2},
+		'D': {'F': 4},
+		'E': {'D': 1, 'F': 2, 'G': 3},
+		'F': {'G': 2, 'H': 1},
+		'G': {'H': 2},
+	}
+	neighbors := func(n utils.WithCost[rune, int]) []utils.WithCost[rune, int] {
+		res := make([]utils.WithCost[rune, int], 0)
+		for to, c := range edges[n.Value] {
+			res = append(res, utils.WithCost[rune, int]{Value: to, Cost: n.Cost + c})
+		}
+		return res
+	}
+	isDone := func(r rune) bool { return r == 'H' }
+
+	paths := utils.KShortestPaths(utils.WithCost[rune, int]{Value: 'C'}, isDone, neighbors, 3)
+	is.Equal(len(paths), 3)
+	is.Equal(string(paths[0].Value), "CEFH")
+	is.Equal(paths[0].Cost, 5)
+	is.Equal(paths[1].Cost, 7)
+	is.Equal(paths[2].Cost, 8)
+	is.Equal(string(paths[1].Value), "CEGH")
+
+	all := utils.KShortestPaths(utils.WithCost[rune, int]{Value: 'C'}, isDone, neighbors, 100)
+	is.Equal(len(all), 7)
+	for i := 1; i < len(all); i++ {
+		is.True(all[i-1].Cost <= all[i].Cost)
+	}
+}
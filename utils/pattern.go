@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"iter"
+	"slices"
+)
+
+// Orientation of a pattern: it is first mirrored left to right if Flipped,
+// then rotated clockwise by Rotation degrees.
+type Orientation struct {
+	Rotation int
+	Flipped  bool
+}
+
+// Match is an occurrence of a pattern, (X, Y) being the top left corner of
+// the oriented template.
+type Match struct {
+	X           int
+	Y           int
+	Orientation Orientation
+}
+
+// Pattern is a template grid to look for in other grids. Template cells holding
+// the wildcard match any value.
+type Pattern[T comparable] struct {
+	Template Grid[T]
+	Wildcard T
+}
+
+func NewPattern[T comparable](template Grid[T], wildcard T) Pattern[T] {
+	return Pattern[T]{Template: template, Wildcard: wildcard}
+}
+
+type orientedTemplate[T any] struct {
+	grid        *Grid[T]
+	orientation Orientation
+}
+
+// orientations returns the distinct oriented templates: a symmetric template
+// gives the same grid for several orientations, and it is only kept once.
+func (p Pattern[T]) orientations() []orientedTemplate[T] {
+	templates := make([]orientedTemplate[T], 0, 8)
+	for _, flipped := range []bool{false, true} {
+		t := p.Template.Clone()
+		if flipped {
+			t = t.FlipHorizontal()
+		}
+		for rotation := 0; rotation < 360; rotation += 90 {
+			duplicate := slices.ContainsFunc(templates, func(o orientedTemplate[T]) bool {
+				return o.grid.Width == t.Width && slices.Equal(o.grid.cells, t.cells)
+			})
+			if !duplicate {
+				templates = append(templates, orientedTemplate[T]{grid: t, orientation: Orientation{Rotation: rotation, Flipped: flipped}})
+			}
+			t = t.Rotate90()
+		}
+	}
+	return templates
+}
+
+func (p Pattern[T]) matchesAt(t Grid[T], g Grid[T], x, y int) bool {
+	for c := range t.AllCells() {
+		if c.Value == p.Wildcard {
+			continue
+		}
+		if g.At(x+c.X-t.MinX, y+c.Y-t.MinY) != c.Value {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Pattern[T]) find(g Grid[T], templates []orientedTemplate[T]) iter.Seq[Match] {
+	return func(yield func(Match) bool) {
+		for _, t := range templates {
+			for y := g.MinY; y+int(t.grid.Height)-1 <= g.MaxY; y++ {
+				for x := g.MinX; x+int(t.grid.Width)-1 <= g.MaxX; x++ {
+					if p.matchesAt(*t.grid, g, x, y) && !yield(Match{X: x, Y: y, Orientation: t.orientation}) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// Find iterates over the occurrences of the template as is.
+func (p Pattern[T]) Find(g Grid[T]) iter.Seq[Match] {
+	return p.find(g, []orientedTemplate[T]{{grid: &p.Template}})
+}
+
+// FindAll iterates over the occurrences of the template in any orientation,
+// rotated and mirrored.
+func (p Pattern[T]) FindAll(g Grid[T]) iter.Seq[Match] {
+	return p.find(g, p.orientations())
+}
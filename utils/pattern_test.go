@@ -0,0 +1,59 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func TestPatternFind(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"ab.ab",
+		".b.ab",
+		"ab...",
+	)
+	p := utils.NewPattern(*runeGrid("a?", "?b"), '?')
+
+	matches := make([]utils.Match, 0)
+	for m := range p.Find(*g) {
+		matches = append(matches, m)
+	}
+	is.Equal(matches, []utils.Match{{X: 0, Y: 0}, {X: 3, Y: 0}})
+}
+
+func TestPatternFindAll(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"xy...",
+		"....y",
+		"...yx",
+	)
+	p := utils.NewPattern(*runeGrid("xy"), '?')
+
+	matches := make([]utils.Match, 0)
+	for m := range p.FindAll(*g) {
+		matches = append(matches, m)
+	}
+	is.Equal(matches, []utils.Match{
+		{X: 0, Y: 0, Orientation: utils.Orientation{Rotation: 0}},
+		{X: 3, Y: 2, Orientation: utils.Orientation{Rotation: 180}},
+		{X: 4, Y: 1, Orientation: utils.Orientation{Rotation: 270}},
+	})
+
+	count := 0
+	symmetric := utils.NewPattern(*runeGrid("x"), '?')
+	for range symmetric.FindAll(*g) {
+		count++
+	}
+	is.Equal(count, 2) // Each x is found once, whatever the orientation
+
+	count = 0
+	for range p.FindAll(*runeGrid("xyx")) {
+		count++
+	}
+	is.Equal(count, 2) // Mirrored xy is the rotated one: not found twice
+}
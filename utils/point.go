@@ -0,0 +1,75 @@
+package utils
+
+type Point struct {
+	X int
+	Y int
+}
+
+func (p Point) XY() (int, int) {
+	return p.X, p.Y
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{X: p.X + q.X, Y: p.Y + q.Y}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+func (p Point) Scale(n int) Point {
+	return Point{X: p.X * n, Y: p.Y * n}
+}
+
+func (p Point) Neg() Point {
+	return Point{X: -p.X, Y: -p.Y}
+}
+
+// Move steps from p in direction d.
+func (p Point) Move(d Direction) Point {
+	return Point{X: p.X + d.Dx, Y: p.Y + d.Dy}
+}
+
+func (p Point) Manhattan(q Point) int {
+	return Abs(p.X-q.X) + Abs(p.Y-q.Y)
+}
+
+func (p Point) Chebyshev(q Point) int {
+	return max(Abs(p.X-q.X), Abs(p.Y-q.Y))
+}
+
+// Neighbors4 returns the points around p, in the order of Dirs4.
+func (p Point) Neighbors4() []Point {
+	return MapTo(Dirs4, p.Move)
+}
+
+// Neighbors8 returns the points around p, in the order of Dirs8.
+func (p Point) Neighbors8() []Point {
+	return MapTo(Dirs8, p.Move)
+}
+
+// Direction is the vector from the origin to p.
+func (p Point) Direction() Direction {
+	return Direction{Dx: p.X, Dy: p.Y}
+}
+
+// Point is the destination of the vector from the origin.
+func (d Direction) Point() Point {
+	return Point{X: d.Dx, Y: d.Dy}
+}
+
+func (c Cell[T]) Point() Point {
+	return Point{X: c.X, Y: c.Y}
+}
+
+func (g Grid[T]) IsPointValid(p Point) bool {
+	return g.IsCoordValid(p.X, p.Y)
+}
+
+func (g Grid[T]) AtP(p Point) T {
+	return g.At(p.X, p.Y)
+}
+
+func (g *Grid[T]) SetP(p Point, value T) {
+	g.Set(p.X, p.Y, value)
+}
@@ -0,0 +1,48 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func TestPointArithmetic(t *testing.T) {
+	is := is.New(t)
+
+	p := utils.Point{X: 2, Y: 3}
+	q := utils.Point{X: -1, Y: 5}
+
+	is.Equal(p.Add(q), utils.Point{X: 1, Y: 8})
+	is.Equal(p.Sub(q), utils.Point{X: 3, Y: -2})
+	is.Equal(p.Scale(2), utils.Point{X: 4, Y: 6})
+	is.Equal(p.Neg(), utils.Point{X: -2, Y: -3})
+	is.Equal(p.Move(utils.DirUp), utils.Point{X: 2, Y: 2})
+	is.Equal(p.Manhattan(q), 5)
+	is.Equal(p.Chebyshev(q), 3)
+	is.Equal(p.Sub(q).Direction().Point(), p.Sub(q))
+}
+
+func TestPointNeighbors(t *testing.T) {
+	is := is.New(t)
+
+	p := utils.Point{X: 0, Y: 0}
+	is.Equal(p.Neighbors4(), []utils.Point{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}})
+	is.Equal(len(p.Neighbors8()), 8)
+	for _, n := range p.Neighbors8() {
+		is.Equal(p.Chebyshev(n), 1)
+	}
+}
+
+func TestGridPoints(t *testing.T) {
+	is := is.New(t)
+
+	g := utils.NewGrid[int](3, 2)
+	p := utils.Point{X: 2, Y: 1}
+	is.True(g.IsPointValid(p))
+	is.True(!g.IsPointValid(p.Move(utils.DirRight)))
+
+	g.SetP(p, 7)
+	is.Equal(g.AtP(p), 7)
+	is.Equal(g.At(2, 1), 7)
+}
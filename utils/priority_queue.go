@@ -6,7 +6,7 @@ import (
 )
 
 // An Item is something we manage in a priority queue.
-type Item[T any, U cmp.Ordered] struct {
+type Item[T any, U any] struct {
 	Value    T // The value of the item; arbitrary.
 	Priority U // The priority of the item in the queue.
 	// The index is needed by update and is maintained by the heap.Interface methods.
@@ -67,9 +67,131 @@ func (pq *priorityQueue[T, U]) Pop() any {
 	return item
 }
 
-// update modifies the priority and value of an Item in the queue.
-func (pq *priorityQueue[T, U]) update(item *Item[T, U], value T, priority U) {
-	item.Value = value
-	item.Priority = priority
-	heap.Fix(pq, item.index)
+// IndexedPriorityQueue holds each value at most once, so that its priority can
+// be changed in place instead of pushing duplicates.
+type IndexedPriorityQueue[T comparable, U any] struct {
+	heap indexedHeap[T, U]
+}
+
+// NewIndexedPriorityQueue pops the lowest priority first.
+func NewIndexedPriorityQueue[T comparable, U cmp.Ordered]() *IndexedPriorityQueue[T, U] {
+	return NewIndexedPriorityQueueFunc(func(a, b Item[T, U]) bool { return a.Priority < b.Priority })
+}
+
+// NewIndexedPriorityQueueFunc pops first the items coming first according to
+// less, e.g. the highest priority for a max-heap, or breaking ties on values.
+func NewIndexedPriorityQueueFunc[T comparable, U any](less func(a, b Item[T, U]) bool) *IndexedPriorityQueue[T, U] {
+	return &IndexedPriorityQueue[T, U]{
+		heap: indexedHeap[T, U]{
+			items: make([]*Item[T, U], 0),
+			byKey: make(map[T]*Item[T, U]),
+			less:  less,
+		},
+	}
+}
+
+// Push adds a value that is not in the queue yet.
+func (pq *IndexedPriorityQueue[T, U]) Push(value T, prio U) {
+	Assert(!pq.Contains(value), "value already in queue: %v", value)
+	heap.Push(&pq.heap, &Item[T, U]{Value: value, Priority: prio})
+}
+
+func (pq *IndexedPriorityQueue[T, U]) Pop() *Item[T, U] {
+	return heap.Pop(&pq.heap).(*Item[T, U])
+}
+
+// Peek returns the next item to be popped, leaving it in the queue.
+func (pq *IndexedPriorityQueue[T, U]) Peek() *Item[T, U] {
+	Assert(!pq.IsEmpty(), "peek on empty queue")
+	return pq.heap.items[0]
+}
+
+func (pq *IndexedPriorityQueue[T, U]) Len() int {
+	return pq.heap.Len()
+}
+
+func (pq *IndexedPriorityQueue[T, U]) IsEmpty() bool {
+	return pq.heap.Len() == 0
+}
+
+func (pq *IndexedPriorityQueue[T, U]) Contains(value T) bool {
+	_, ok := pq.heap.byKey[value]
+	return ok
+}
+
+// Priority returns the current priority of a value in the queue.
+func (pq *IndexedPriorityQueue[T, U]) Priority(value T) (U, bool) {
+	item, ok := pq.heap.byKey[value]
+	if !ok {
+		var zero U
+		return zero, false
+	}
+	return item.Priority, true
+}
+
+// Update changes the priority of a value in the queue, or pushes it.
+func (pq *IndexedPriorityQueue[T, U]) Update(value T, prio U) {
+	item, ok := pq.heap.byKey[value]
+	if !ok {
+		pq.Push(value, prio)
+		return
+	}
+	item.Priority = prio
+	heap.Fix(&pq.heap, item.index)
+}
+
+// DecreaseKey moves a value in the queue closer to the front. Nothing changes,
+// and it returns false, if the value is not in the queue or the new priority
+// would not come before the current one.
+func (pq *IndexedPriorityQueue[T, U]) DecreaseKey(value T, prio U) bool {
+	item, ok := pq.heap.byKey[value]
+	if !ok || !pq.heap.less(Item[T, U]{Value: value, Priority: prio}, *item) {
+		return false
+	}
+	item.Priority = prio
+	heap.Fix(&pq.heap, item.index)
+	return true
+}
+
+// Remove takes a value out of the queue, returning false if it was not in.
+func (pq *IndexedPriorityQueue[T, U]) Remove(value T) bool {
+	item, ok := pq.heap.byKey[value]
+	if !ok {
+		return false
+	}
+	heap.Remove(&pq.heap, item.index)
+	return true
+}
+
+type indexedHeap[T comparable, U any] struct {
+	items []*Item[T, U]
+	byKey map[T]*Item[T, U]
+	less  func(a, b Item[T, U]) bool
+}
+
+func (h indexedHeap[T, U]) Len() int { return len(h.items) }
+
+func (h indexedHeap[T, U]) Less(i, j int) bool { return h.less(*h.items[i], *h.items[j]) }
+
+func (h indexedHeap[T, U]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *indexedHeap[T, U]) Push(x any) {
+	item := x.(*Item[T, U])
+	item.index = len(h.items)
+	h.items = append(h.items, item)
+	h.byKey[item.Value] = item
+}
+
+func (h *indexedHeap[T, U]) Pop() any {
+	n := len(h.items)
+	item := h.items[n-1]
+	h.items[n-1] = nil
+	item.index = -1
+	h.items = h.items[:n-1]
+	delete(h.byKey, item.Value)
+	return item
 }
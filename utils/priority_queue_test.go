@@ -0,0 +1,68 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func TestIndexedPriorityQueue(t *testing.T) {
+	is := is.New(t)
+
+	pq := utils.NewIndexedPriorityQueue[string, int]()
+	pq.Push("a", 5)
+	pq.Push("b", 3)
+	pq.Push("c", 8)
+	pq.Push("d", 1)
+
+	is.Equal(pq.Len(), 4)
+	is.True(pq.Contains("c"))
+	is.Equal(pq.Peek().Value, "d")
+
+	is.True(pq.DecreaseKey("c", 0))
+	is.True(!pq.DecreaseKey("a", 6))
+	is.True(!pq.DecreaseKey("z", 0))
+	is.Equal(pq.Peek().Value, "c")
+
+	pq.Update("b", 10)
+	prio, ok := pq.Priority("b")
+	is.True(ok)
+	is.Equal(prio, 10)
+
+	is.True(pq.Remove("d"))
+	is.True(!pq.Remove("d"))
+	is.True(!pq.Contains("d"))
+
+	popped := make([]string, 0)
+	for !pq.IsEmpty() {
+		popped = append(popped, pq.Pop().Value)
+	}
+	is.Equal(popped, []string{"c", "a", "b"})
+	is.True(!pq.Contains("c"))
+}
+
+func TestIndexedPriorityQueueFunc(t *testing.T) {
+	is := is.New(t)
+
+	// Max-heap, ties broken on the value.
+	pq := utils.NewIndexedPriorityQueueFunc(func(a, b utils.Item[string, int]) bool {
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return a.Value < b.Value
+	})
+	pq.Push("b", 2)
+	pq.Push("a", 2)
+	pq.Push("c", 1)
+	pq.Push("d", 3)
+
+	is.True(pq.DecreaseKey("c", 4))
+	is.True(!pq.DecreaseKey("d", 1))
+
+	popped := make([]string, 0)
+	for !pq.IsEmpty() {
+		popped = append(popped, pq.Pop().Value)
+	}
+	is.Equal(popped, []string{"c", "d", "a", "b"})
+}
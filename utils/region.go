@@ -0,0 +1,82 @@
+package utils
+
+// Region is a set of connected cells of a grid.
+type Region[T any] struct {
+	Label int
+	Cells []Cell[T]
+
+	MinX int
+	MinY int
+	MaxX int
+	MaxY int
+}
+
+func (r Region[T]) Area() int {
+	return len(r.Cells)
+}
+
+func (r Region[T]) Width() uint {
+	return uint(r.MaxX - r.MinX + 1)
+}
+
+func (r Region[T]) Height() uint {
+	return uint(r.MaxY - r.MinY + 1)
+}
+
+func (r *Region[T]) add(c Cell[T]) {
+	if len(r.Cells) == 0 {
+		r.MinX, r.MaxX, r.MinY, r.MaxY = c.X, c.X, c.Y, c.Y
+	} else {
+		r.MinX, r.MaxX = min(r.MinX, c.X), max(r.MaxX, c.X)
+		r.MinY, r.MaxY = min(r.MinY, c.Y), max(r.MaxY, c.Y)
+	}
+	r.Cells = append(r.Cells, c)
+}
+
+// FloodFill returns the region containing (x, y). Two neighbor cells are in the
+// same region when sameRegion returns true for their values.
+func (n GridNeighbor[T]) FloodFill(g Grid[T], x, y int, sameRegion func(a, b T) bool) Region[T] {
+	visited := NewGridEx[bool](g.Width, g.Height, g.MinX, g.MinY)
+
+	return n.fill(g, visited, x, y, sameRegion)
+}
+
+func (n GridNeighbor[T]) fill(g Grid[T], visited *Grid[bool], x, y int, sameRegion func(a, b T) bool) Region[T] {
+	var region Region[T]
+	region.add(Cell[T]{X: x, Y: y, Value: g.At(x, y)})
+	visited.Set(x, y, true)
+
+	for idx := 0; idx < len(region.Cells); idx++ {
+		current := region.Cells[idx]
+		for _, c := range n.NeighborCells(g, current.X, current.Y) {
+			if !visited.At(c.X, c.Y) && sameRegion(current.Value, c.Value) {
+				region.add(c)
+				visited.Set(c.X, c.Y, true)
+			}
+		}
+	}
+
+	return region
+}
+
+// Components splits the grid into regions. The label grid gives the index of
+// the region of each cell.
+func (n GridNeighbor[T]) Components(g Grid[T], sameRegion func(a, b T) bool) (*Grid[int], []Region[T]) {
+	labels := NewGridEx[int](g.Width, g.Height, g.MinX, g.MinY)
+	visited := NewGridEx[bool](g.Width, g.Height, g.MinX, g.MinY)
+	regions := make([]Region[T], 0)
+
+	for c := range g.AllCells() {
+		if visited.At(c.X, c.Y) {
+			continue
+		}
+		region := n.fill(g, visited, c.X, c.Y, sameRegion)
+		region.Label = len(regions)
+		for _, rc := range region.Cells {
+			labels.Set(rc.X, rc.Y, region.Label)
+		}
+		regions = append(regions, region)
+	}
+
+	return labels, regions
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// A region boundary is made of unit edges between grid points: the cell (x, y)
+// is the square between the points (x, y) and (x+1, y+1). Edges go around the
+// region clockwise, with the region on their right, so holes go
+// counterclockwise.
+
+// Corner is a grid point where the boundary turns. Convex corners turn toward
+// the region.
+type Corner struct {
+	X      int
+	Y      int
+	Convex bool
+}
+
+// Polygon is a closed boundary given by its corners in order.
+type Polygon struct {
+	Corners []Corner
+	Hole    bool
+}
+
+type RegionGeometry struct {
+	// Perimeter is the number of unit edges.
+	Perimeter int
+	// Sides is the number of straight sides, which is also the number of corners.
+	Sides    int
+	Corners  []Corner
+	Polygons []Polygon
+}
+
+type edge struct {
+	from Point
+	dir  Direction
+}
+
+func (e edge) to() Point {
+	return e.from.Move(e.dir)
+}
+
+func (r Region[T]) Geometry() RegionGeometry {
+	cells := NewSet[Point]()
+	for _, c := range r.Cells {
+		cells.Add(c.Point())
+	}
+
+	edges := NewSet[edge]()
+	for c := range cells {
+		if !cells.Exists(Point{X: c.X, Y: c.Y - 1}) {
+			edges.Add(edge{from: Point{X: c.X, Y: c.Y}, dir: DirRight})
+		}
+		if !cells.Exists(Point{X: c.X + 1, Y: c.Y}) {
+			edges.Add(edge{from: Point{X: c.X + 1, Y: c.Y}, dir: DirDown})
+		}
+		if !cells.Exists(Point{X: c.X, Y: c.Y + 1}) {
+			edges.Add(edge{from: Point{X: c.X + 1, Y: c.Y + 1}, dir: DirLeft})
+		}
+		if !cells.Exists(Point{X: c.X - 1, Y: c.Y}) {
+			edges.Add(edge{from: Point{X: c.X, Y: c.Y + 1}, dir: DirUp})
+		}
+	}
+
+	// The next edge prefers turning right: where the region touches itself
+	// diagonally, each boundary stays around its own cells. Diagonal cells
+	// outside the region end up in the same boundary, like two diagonal holes.
+	next := func(e edge) edge {
+		for _, d := range []Direction{e.dir.TurnRight(), e.dir, e.dir.TurnLeft()} {
+			n := edge{from: e.to(), dir: d}
+			if edges.Exists(n) {
+				return n
+			}
+		}
+		Assert(false, "open boundary at %v", e.to())
+		return e
+	}
+
+	geometry := RegionGeometry{Perimeter: len(edges), Corners: make([]Corner, 0), Polygons: make([]Polygon, 0)}
+
+	sorted := slices.SortedFunc(maps.Keys(edges), func(a, b edge) int {
+		return cmp.Or(cmp.Compare(a.from.Y, b.from.Y), cmp.Compare(a.from.X, b.from.X))
+	})
+	visited := NewSet[edge]()
+	for _, start := range sorted {
+		if visited.Exists(start) {
+			continue
+		}
+
+		polygon := Polygon{Corners: make([]Corner, 0)}
+		area := 0
+		for e := start; !visited.Exists(e); e = next(e) {
+			visited.Add(e)
+			n := next(e)
+			if n.dir != e.dir {
+				polygon.Corners = append(polygon.Corners, Corner{X: n.from.X, Y: n.from.Y, Convex: n.dir == e.dir.TurnRight()})
+			}
+			to := e.to()
+			area += e.from.X*to.Y - to.X*e.from.Y
+		}
+		polygon.Hole = area < 0
+
+		geometry.Corners = append(geometry.Corners, polygon.Corners...)
+		geometry.Polygons = append(geometry.Polygons, polygon)
+	}
+	geometry.Sides = len(geometry.Corners)
+
+	return geometry
+}
@@ -0,0 +1,105 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func sameRune(a, b rune) bool {
+	return a == b
+}
+
+func TestFloodFill(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"AAB",
+		"BAB",
+		"BBA",
+	)
+
+	r := utils.NewNeighbors4[rune]().FloodFill(*g, 0, 0, sameRune)
+	is.Equal(r.Area(), 3)
+	is.Equal([]int{r.MinX, r.MinY, r.MaxX, r.MaxY}, []int{0, 0, 1, 1})
+
+	r = utils.NewNeighbors8[rune]().FloodFill(*g, 0, 0, sameRune)
+	is.Equal(r.Area(), 4) // The last A touches diagonally
+	is.Equal(r.Width(), uint(3))
+}
+
+func TestComponents(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"AAB",
+		"BAB",
+		"BBA",
+	)
+
+	labels, regions := utils.NewNeighbors4[rune]().Components(*g, sameRune)
+	is.Equal(len(regions), 4)
+	is.Equal(labels.At(0, 0), labels.At(1, 1))
+	is.True(labels.At(2, 0) != labels.At(0, 1))
+	for _, r := range regions {
+		for _, c := range r.Cells {
+			is.Equal(labels.At(c.X, c.Y), r.Label)
+		}
+	}
+}
+
+func TestRegionGeometry(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"EEEEE",
+		"EXXXX",
+		"EEEEE",
+		"EXXXX",
+		"EEEEE",
+	)
+
+	r := utils.NewNeighbors4[rune]().FloodFill(*g, 0, 0, sameRune)
+	geometry := r.Geometry()
+	is.Equal(geometry.Perimeter, 36)
+	is.Equal(geometry.Sides, 12)
+	is.Equal(len(geometry.Polygons), 1)
+	is.Equal(geometry.Polygons[0].Corners[len(geometry.Polygons[0].Corners)-1], utils.Corner{X: 0, Y: 0, Convex: true})
+
+	r = utils.NewNeighbors4[rune]().FloodFill(*g, 1, 1, sameRune)
+	is.Equal(r.Geometry().Perimeter, 10)
+	is.Equal(r.Geometry().Sides, 4)
+}
+
+func TestRegionGeometryWithHoles(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"AAAAAA",
+		"AAABBA",
+		"AAABBA",
+		"ABBAAA",
+		"ABBAAA",
+		"AAAAAA",
+	)
+
+	geometry := utils.NewNeighbors4[rune]().FloodFill(*g, 0, 0, sameRune).Geometry()
+	is.Equal(geometry.Sides, 12)
+	is.Equal(len(geometry.Polygons), 2) // The B plots touch: one hole
+
+	holes := 0
+	concave := 0
+	for _, p := range geometry.Polygons {
+		if p.Hole {
+			holes++
+		}
+	}
+	for _, c := range geometry.Corners {
+		if !c.Convex {
+			concave++
+		}
+	}
+	is.Equal(holes, 1)
+	is.Equal(concave, 6) // The hole corners, except where the B plots touch
+}
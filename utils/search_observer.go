@@ -0,0 +1,60 @@
+package utils
+
+import "context"
+
+type SearchEventKind int
+
+const (
+	// SearchPush is sent when a node enters the frontier.
+	SearchPush SearchEventKind = iota
+	// SearchSettle is sent when a node leaves the frontier with its final cost.
+	SearchSettle
+	// SearchRelax is sent for each edge examined from a settled node, before
+	// the neighbor is pushed if its cost improved.
+	SearchRelax
+)
+
+func (k SearchEventKind) String() string {
+	switch k {
+	case SearchPush:
+		return "push"
+	case SearchSettle:
+		return "settle"
+	case SearchRelax:
+		return "relax"
+	}
+	return "unknown"
+}
+
+// SearchEvent describes a step of a search. Parent is the node the event comes
+// from, the zero value for the start nodes.
+type SearchEvent[T any, U any] struct {
+	Kind   SearchEventKind
+	Value  T
+	Parent T
+	Cost   U
+}
+
+// SearchObserver follows the progress of a search: Dijkstra, DijkstraAll, AStar
+// and the BFS functions take one as last argument, nil for none. Searches stop
+// as soon as its context is done, returning what they found so far.
+type SearchObserver[T any, U any] struct {
+	ctx     context.Context
+	onEvent func(SearchEvent[T, U])
+}
+
+// NewSearchObserver calls onEvent for each event of the search, if not nil.
+func NewSearchObserver[T any, U any](ctx context.Context, onEvent func(SearchEvent[T, U])) *SearchObserver[T, U] {
+	return &SearchObserver[T, U]{ctx: ctx, onEvent: onEvent}
+}
+
+func (o *SearchObserver[T, U]) notify(kind SearchEventKind, value T, parent T, cost U) {
+	if o == nil || o.onEvent == nil {
+		return
+	}
+	o.onEvent(SearchEvent[T, U]{Kind: kind, Value: value, Parent: parent, Cost: cost})
+}
+
+func (o *SearchObserver[T, U]) cancelled() bool {
+	return o != nil && o.ctx != nil && o.ctx.Err() != nil
+}
@@ -0,0 +1,64 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func TestSearchObserver(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"...",
+		".#.",
+		"...",
+	)
+	passable := func(r rune) bool { return r != '#' }
+
+	counts := make(map[utils.SearchEventKind]int)
+	settled := make([]int, 0)
+	parents := make(map[utils.Point]utils.Point)
+	observer := utils.NewSearchObserver(context.Background(), func(e utils.SearchEvent[utils.Point, int]) {
+		counts[e.Kind]++
+		if e.Kind == utils.SearchSettle {
+			settled = append(settled, e.Cost)
+			parents[e.Value] = e.Parent
+		}
+	})
+	utils.BFSDistances(*g, []utils.Point{{X: 0, Y: 0}}, passable, observer)
+
+	is.Equal(counts[utils.SearchPush], 8)
+	is.Equal(counts[utils.SearchSettle], 8)
+	is.Equal(counts[utils.SearchRelax], 7)
+	is.Equal(settled, []int{0, 1, 1, 2, 2, 3, 3, 4})
+	is.Equal(parents[utils.Point{X: 1, Y: 0}], utils.Point{X: 0, Y: 0})
+	is.Equal(parents[utils.Point{X: 2, Y: 2}].Manhattan(utils.Point{X: 2, Y: 2}), 1)
+}
+
+func TestSearchObserverCancel(t *testing.T) {
+	is := is.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	settled := 0
+	observer := utils.NewSearchObserver(ctx, func(e utils.SearchEvent[int, int]) {
+		if e.Kind == utils.SearchSettle {
+			settled++
+			if settled == 10 {
+				cancel()
+			}
+		}
+	})
+
+	// An infinite line of nodes, never done.
+	neighbors := func(n utils.WithCost[int, int]) []utils.WithCost[int, int] {
+		return []utils.WithCost[int, int]{{Value: n.Value + 1, Cost: n.Cost + 1}}
+	}
+	never := func(int) bool { return false }
+
+	_, _, ok := utils.Dijkstra(utils.WithCost[int, int]{}, never, neighbors, observer)
+	is.True(!ok)
+	is.Equal(settled, 10)
+}
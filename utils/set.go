@@ -1,7 +1,12 @@
 package utils
 
 import (
+	"bytes"
+	"cmp"
+	"encoding/json"
 	"fmt"
+	"iter"
+	"maps"
 	"slices"
 	"strings"
 )
@@ -12,6 +17,22 @@ func NewSet[T comparable]() Set[T] {
 	return make(Set[T])
 }
 
+func SetOf[T comparable](values ...T) Set[T] {
+	s := make(Set[T], len(values))
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+func FromSeq[T comparable](seq iter.Seq[T]) Set[T] {
+	s := NewSet[T]()
+	for v := range seq {
+		s.Add(v)
+	}
+	return s
+}
+
 func (s Set[T]) Add(value T) {
 	s[value] = struct{}{}
 }
@@ -25,12 +46,14 @@ func (s Set[T]) Delete(value T) {
 	delete(s, value)
 }
 
+// Union adds the values of other to s.
 func (s Set[T]) Union(other Set[T]) {
 	for v := range other {
 		s.Add(v)
 	}
 }
 
+// Intersection removes from s the values not in other.
 func (s Set[T]) Intersection(other Set[T]) {
 	for v := range s {
 		if !other.Exists(v) {
@@ -39,6 +62,110 @@ func (s Set[T]) Intersection(other Set[T]) {
 	}
 }
 
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Clone is never nil, even for a nil set.
+func (s Set[T]) Clone() Set[T] {
+	clone := make(Set[T], len(s))
+	maps.Copy(clone, s)
+	return clone
+}
+
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// The functions below leave their arguments untouched and return new sets.
+
+func UnionOf[T comparable](sets ...Set[T]) Set[T] {
+	union := NewSet[T]()
+	for _, s := range sets {
+		union.Union(s)
+	}
+	return union
+}
+
+func IntersectionOf[T comparable](sets ...Set[T]) Set[T] {
+	if len(sets) == 0 {
+		return NewSet[T]()
+	}
+	intersection := sets[0].Clone()
+	for _, s := range sets[1:] {
+		intersection.Intersection(s)
+	}
+	return intersection
+}
+
+// Difference returns the values of s not in other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	difference := NewSet[T]()
+	for v := range s {
+		if !other.Exists(v) {
+			difference.Add(v)
+		}
+	}
+	return difference
+}
+
+// SymmetricDifference returns the values in only one of the sets.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	difference := s.Difference(other)
+	difference.Union(other.Difference(s))
+	return difference
+}
+
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for v := range s {
+		if !other.Exists(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// SortedFunc iterates over the values in the order given by cmp.
+func (s Set[T]) SortedFunc(cmp func(a, b T) int) iter.Seq[T] {
+	return slices.Values(slices.SortedFunc(s.All(), cmp))
+}
+
+// Sorted iterates over the values in increasing order.
+func Sorted[T cmp.Ordered](s Set[T]) iter.Seq[T] {
+	return slices.Values(slices.Sorted(s.All()))
+}
+
+// MarshalJSON writes the set as an array, sorted on the JSON of the values to
+// be deterministic.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	values := make([]json.RawMessage, 0, len(s))
+	for v := range s {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, b)
+	}
+	slices.SortFunc(values, func(a, b json.RawMessage) int { return bytes.Compare(a, b) })
+	return json.Marshal(values)
+}
+
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*s = SetOf(values...)
+	return nil
+}
+
 func (s Set[T]) String() string {
 	keys := make([]string, 0, len(s))
 	for k := range s {
@@ -0,0 +1,63 @@
+package utils_test
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func TestSetOperations(t *testing.T) {
+	is := is.New(t)
+
+	a := utils.SetOf(1, 2, 3)
+	b := utils.SetOf(3, 4)
+
+	is.Equal(utils.UnionOf(a, b).String(), "{1,2,3,4}")
+	is.Equal(utils.IntersectionOf(a, b).String(), "{3}")
+	is.Equal(utils.IntersectionOf[int]().Len(), 0)
+	is.Equal(a.Difference(b).String(), "{1,2}")
+	is.Equal(a.SymmetricDifference(b).String(), "{1,2,4}")
+	is.Equal(a.String(), "{1,2,3}")
+	is.Equal(b.String(), "{3,4}")
+
+	is.True(utils.SetOf(1, 3).IsSubset(a))
+	is.True(!b.IsSubset(a))
+	is.True(a.Equal(utils.SetOf(3, 2, 1)))
+	is.True(!a.Equal(b))
+
+	c := a.Clone()
+	c.Add(9)
+	is.Equal(a.Len(), 3)
+	is.Equal(c.Len(), 4)
+
+	var empty utils.Set[int]
+	empty.Clone().Add(1)
+	utils.IntersectionOf(empty, a).Add(1)
+}
+
+func TestSetIteration(t *testing.T) {
+	is := is.New(t)
+
+	s := utils.FromSeq(slices.Values([]string{"b", "c", "a", "b"}))
+	is.Equal(s.Len(), 3)
+	is.Equal(slices.Collect(utils.Sorted(s)), []string{"a", "b", "c"})
+	is.Equal(slices.Collect(s.SortedFunc(func(a, b string) int { return strings.Compare(b, a) })), []string{"c", "b", "a"})
+	is.Equal(len(slices.Collect(s.All())), 3)
+}
+
+func TestSetJSON(t *testing.T) {
+	is := is.New(t)
+
+	points := utils.SetOf(utils.Point{X: 2, Y: 1}, utils.Point{X: 1, Y: 5})
+	data, err := json.Marshal(points)
+	is.NoErr(err)
+	is.Equal(string(data), `[{"X":1,"Y":5},{"X":2,"Y":1}]`)
+
+	var decoded utils.Set[utils.Point]
+	is.NoErr(json.Unmarshal(data, &decoded))
+	is.True(decoded.Equal(points))
+}
@@ -0,0 +1,175 @@
+package utils
+
+import "slices"
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// The searches below follow the contract of Dijkstra, for graphs with small
+// integer edge weights: the weight of an edge is the cost of the neighbor minus
+// the cost of the current node.
+
+// ZeroOneBFS is a Dijkstra for edges weighing 0 or 1. Nodes reached by a free
+// edge go to the front of a deque, the others to the back.
+func ZeroOneBFS[T comparable, U Integer](start WithCost[T, U], isDone func(T) bool, neighbors func(WithCost[T, U]) []WithCost[T, U], obs *SearchObserver[T, U]) ([]T, U, bool) {
+	var root T
+
+	queue := deque[T]{}
+	queue.PushBack(start.Value)
+	obs.notify(SearchPush, start.Value, root, start.Cost)
+	settled := NewSet[T]()
+	parent := make(map[T]T)
+	cost := map[T]U{start.Value: start.Cost}
+
+	for queue.Len() > 0 {
+		current := queue.PopFront()
+		if settled.Exists(current) {
+			continue
+		}
+		if obs.cancelled() {
+			break
+		}
+		settled.Add(current)
+		obs.notify(SearchSettle, current, parent[current], cost[current])
+
+		if isDone(current) {
+			return pathTo(parent, current), cost[current], true
+		}
+
+		for _, n := range neighbors(WithCost[T, U]{current, cost[current]}) {
+			Assert(n.Cost == cost[current] || n.Cost == cost[current]+1, "0-1 BFS on an edge weighing %v", n.Cost-cost[current])
+			if settled.Exists(n.Value) {
+				continue
+			}
+			weight := n.Cost - cost[current]
+			obs.notify(SearchRelax, n.Value, current, n.Cost)
+			if c, ok := cost[n.Value]; ok && c <= n.Cost {
+				continue
+			}
+			cost[n.Value] = n.Cost
+			parent[n.Value] = current
+			if weight == 0 {
+				queue.PushFront(n.Value)
+			} else {
+				queue.PushBack(n.Value)
+			}
+			obs.notify(SearchPush, n.Value, current, n.Cost)
+		}
+	}
+
+	return nil, start.Cost, false
+}
+
+// DialDijkstra is a Dijkstra for edges weighing between 0 and maxWeight. Nodes
+// wait in maxWeight+1 buckets indexed by their cost, modulo the number of
+// buckets, so maxWeight must stay small.
+func DialDijkstra[T comparable, U Integer](start WithCost[T, U], isDone func(T) bool, neighbors func(WithCost[T, U]) []WithCost[T, U], maxWeight U, obs *SearchObserver[T, U]) ([]T, U, bool) {
+	var root T
+
+	size := maxWeight + 1
+	Assert(maxWeight >= 0 && size > maxWeight && int(size) > 0 && U(int(size)) == size, "%v buckets do not fit in an int", maxWeight)
+	buckets := make([][]T, int(size))
+	bucket := func(c U) int { return Mod(int(c%size), int(size)) }
+	buckets[bucket(start.Cost)] = append(buckets[bucket(start.Cost)], start.Value)
+	obs.notify(SearchPush, start.Value, root, start.Cost)
+	waiting := 1
+	settled := NewSet[T]()
+	parent := make(map[T]T)
+	cost := map[T]U{start.Value: start.Cost}
+
+	for current := start.Cost; waiting > 0; current++ {
+		b := bucket(current)
+		for len(buckets[b]) > 0 {
+			node := buckets[b][len(buckets[b])-1]
+			buckets[b] = buckets[b][:len(buckets[b])-1]
+			waiting--
+			if settled.Exists(node) || cost[node] != current {
+				continue
+			}
+			if obs.cancelled() {
+				return nil, start.Cost, false
+			}
+			settled.Add(node)
+			obs.notify(SearchSettle, node, parent[node], current)
+
+			if isDone(node) {
+				return pathTo(parent, node), current, true
+			}
+
+			for _, n := range neighbors(WithCost[T, U]{node, current}) {
+				Assert(n.Cost >= current && n.Cost-current <= maxWeight, "edge weighing %v, not within 0 and %v", n.Cost-current, maxWeight)
+				if settled.Exists(n.Value) {
+					continue
+				}
+				obs.notify(SearchRelax, n.Value, node, n.Cost)
+				if c, ok := cost[n.Value]; ok && c <= n.Cost {
+					continue
+				}
+				cost[n.Value] = n.Cost
+				parent[n.Value] = node
+				buckets[bucket(n.Cost)] = append(buckets[bucket(n.Cost)], n.Value)
+				waiting++
+				obs.notify(SearchPush, n.Value, node, n.Cost)
+			}
+		}
+	}
+
+	return nil, start.Cost, false
+}
+
+// pathTo follows the parents back from end to the start.
+func pathTo[T comparable](parent map[T]T, end T) []T {
+	path := []T{end}
+	for node, ok := parent[end]; ok; node, ok = parent[node] {
+		path = append(path, node)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// deque is a double-ended queue over a ring buffer.
+type deque[T any] struct {
+	items []T
+	head  int
+	size  int
+}
+
+func (d *deque[T]) Len() int {
+	return d.size
+}
+
+func (d *deque[T]) grow() {
+	if d.size < len(d.items) {
+		return
+	}
+	items := make([]T, max(8, 2*len(d.items)))
+	for i := 0; i < d.size; i++ {
+		items[i] = d.items[(d.head+i)%len(d.items)]
+	}
+	d.items = items
+	d.head = 0
+}
+
+func (d *deque[T]) PushBack(v T) {
+	d.grow()
+	d.items[(d.head+d.size)%len(d.items)] = v
+	d.size++
+}
+
+func (d *deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.items)) % len(d.items)
+	d.items[d.head] = v
+	d.size++
+}
+
+func (d *deque[T]) PopFront() T {
+	Assert(d.size > 0, "pop on empty deque")
+	var zero T
+	v := d.items[d.head]
+	d.items[d.head] = zero
+	d.head = (d.head + 1) % len(d.items)
+	d.size--
+	return v
+}
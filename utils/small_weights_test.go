@@ -0,0 +1,92 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+type reindeer struct {
+	Pos utils.Point
+	Dir utils.Direction
+}
+
+func TestZeroOneBFS(t *testing.T) {
+	is := is.New(t)
+
+	// Walking costs nothing, breaking a wall costs 1.
+	g := runeGrid(
+		".#...",
+		".#.#.",
+		"##.#.",
+		"...#.",
+	)
+	end := utils.Point{X: 4, Y: 3}
+	neighbors := func(p utils.WithCost[utils.Point, int]) []utils.WithCost[utils.Point, int] {
+		res := make([]utils.WithCost[utils.Point, int], 0, 4)
+		for _, n := range p.Value.Neighbors4() {
+			if !g.IsPointValid(n) {
+				continue
+			}
+			c := p.Cost
+			if g.AtP(n) == '#' {
+				c++
+			}
+			res = append(res, utils.WithCost[utils.Point, int]{Value: n, Cost: c})
+		}
+		return res
+	}
+	isDone := func(p utils.Point) bool { return p == end }
+
+	path, cost, ok := utils.ZeroOneBFS(utils.WithCost[utils.Point, int]{}, isDone, neighbors, nil)
+	is.True(ok)
+	is.Equal(cost, 1)
+	is.Equal(path[0], utils.Point{})
+	is.Equal(path[len(path)-1], end)
+
+	g.Set(4, 3, '#')
+	_, cost, ok = utils.ZeroOneBFS(utils.WithCost[utils.Point, int]{}, isDone, neighbors, nil)
+	is.True(ok)
+	is.Equal(cost, 2)
+}
+
+func TestDialDijkstra(t *testing.T) {
+	is := is.New(t)
+
+	g := runeGrid(
+		"#######",
+		"#....E#",
+		"#.#.#.#",
+		"#...#.#",
+		"#S#...#",
+		"#######",
+	)
+	end := utils.Point{X: 5, Y: 1}
+	neighbors := func(r utils.WithCost[reindeer, int]) []utils.WithCost[reindeer, int] {
+		res := []utils.WithCost[reindeer, int]{
+			{Value: reindeer{Pos: r.Value.Pos, Dir: r.Value.Dir.TurnLeft()}, Cost: r.Cost + 1000},
+			{Value: reindeer{Pos: r.Value.Pos, Dir: r.Value.Dir.TurnRight()}, Cost: r.Cost + 1000},
+		}
+		if next := r.Value.Pos.Move(r.Value.Dir); g.AtP(next) != '#' {
+			res = append(res, utils.WithCost[reindeer, int]{Value: reindeer{Pos: next, Dir: r.Value.Dir}, Cost: r.Cost + 1})
+		}
+		return res
+	}
+	isDone := func(r reindeer) bool { return r.Pos == end }
+	start := utils.WithCost[reindeer, int]{Value: reindeer{Pos: utils.Point{X: 1, Y: 4}, Dir: utils.DirRight}}
+
+	path, cost, ok := utils.DialDijkstra(start, isDone, neighbors, 1000, nil)
+	is.True(ok)
+	is.Equal(cost, 2007)
+	is.Equal(path[len(path)-1].Pos, end)
+
+	_, expected, _, _ := utils.AStar(start, isDone, neighbors, func(reindeer) int { return 0 }, nil)
+	is.Equal(cost, expected)
+
+	// Costs can be negative, only weights cannot.
+	start.Cost = -5000
+	_, cost, ok = utils.DialDijkstra(start, isDone, neighbors, 1000, nil)
+	is.True(ok)
+	is.Equal(cost, 2007-5000)
+}
@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// SparseGrid is a map backed grid without fixed bounds: any coordinate can be
+// set, and unset cells hold the Default value. The bounds grow to include every
+// set cell.
+type SparseGrid[T any] struct {
+	Default T
+	cells   map[Point]T
+
+	MinX int
+	MinY int
+	MaxX int
+	MaxY int
+}
+
+func NewSparseGrid[T any](defaultValue T) *SparseGrid[T] {
+	return &SparseGrid[T]{
+		Default: defaultValue,
+		cells:   make(map[Point]T),
+	}
+}
+
+// SparseGridFrom copies all the cells of a dense grid. The bounds are the ones
+// of the dense grid.
+func SparseGridFrom[T any](g Grid[T], defaultValue T) *SparseGrid[T] {
+	s := NewSparseGrid(defaultValue)
+	for c := range g.AllCells() {
+		s.Set(c.X, c.Y, c.Value)
+	}
+	return s
+}
+
+func (g SparseGrid[T]) IsEmpty() bool {
+	return len(g.cells) == 0
+}
+
+func (g SparseGrid[T]) Width() uint {
+	if g.IsEmpty() {
+		return 0
+	}
+	return uint(g.MaxX - g.MinX + 1)
+}
+
+func (g SparseGrid[T]) Height() uint {
+	if g.IsEmpty() {
+		return 0
+	}
+	return uint(g.MaxY - g.MinY + 1)
+}
+
+// IsCoordValid tells if the coordinates are within the current bounds.
+func (g SparseGrid[T]) IsCoordValid(x, y int) bool {
+	return !g.IsEmpty() && x >= g.MinX && x <= g.MaxX && y >= g.MinY && y <= g.MaxY
+}
+
+func (g SparseGrid[T]) IsSet(x, y int) bool {
+	_, ok := g.cells[Point{X: x, Y: y}]
+	return ok
+}
+
+func (g SparseGrid[T]) At(x, y int) T {
+	if v, ok := g.cells[Point{X: x, Y: y}]; ok {
+		return v
+	}
+	return g.Default
+}
+
+func (g *SparseGrid[T]) Set(x, y int, value T) {
+	if g.IsEmpty() {
+		g.MinX, g.MaxX, g.MinY, g.MaxY = x, x, y, y
+	} else {
+		g.MinX, g.MaxX = min(g.MinX, x), max(g.MaxX, x)
+		g.MinY, g.MaxY = min(g.MinY, y), max(g.MaxY, y)
+	}
+	g.cells[Point{X: x, Y: y}] = value
+}
+
+// Unset resets a cell to the default value. Bounds never shrink.
+func (g *SparseGrid[T]) Unset(x, y int) {
+	delete(g.cells, Point{X: x, Y: y})
+}
+
+// Len is the number of set cells.
+func (g SparseGrid[T]) Len() int {
+	return len(g.cells)
+}
+
+func (g SparseGrid[T]) Count(filter func(Cell[T]) bool) int {
+	count := 0
+	for c := range g.AllCells() {
+		if filter(c) {
+			count++
+		}
+	}
+	return count
+}
+
+// AllCells iterates over the set cells, row by row.
+func (g SparseGrid[T]) AllCells() iter.Seq[Cell[T]] {
+	return func(yield func(Cell[T]) bool) {
+		keys := slices.SortedFunc(maps.Keys(g.cells), func(a, b Point) int {
+			return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+		})
+		for _, k := range keys {
+			if !yield(Cell[T]{X: k.X, Y: k.Y, Value: g.cells[k]}) {
+				return
+			}
+		}
+	}
+}
+
+func (g SparseGrid[T]) Clone() *SparseGrid[T] {
+	clone := g
+	clone.cells = maps.Clone(g.cells)
+	return &clone
+}
+
+// ToGrid creates a dense grid covering the bounds, unset cells holding the
+// default value.
+func (g SparseGrid[T]) ToGrid() *Grid[T] {
+	dense := NewGridEx[T](g.Width(), g.Height(), g.MinX, g.MinY)
+	dense.SetAll(g.Default)
+	for k, v := range g.cells {
+		dense.Set(k.X, k.Y, v)
+	}
+	return dense
+}
+
+func (g SparseGrid[T]) String() string {
+	return g.ToGrid().String()
+}
+
+func (g SparseGrid[T]) Stringf(format func(T) string) string {
+	return g.ToGrid().Stringf(format)
+}
+
+func (g SparseGrid[T]) StringDots(filter func(T) bool) string {
+	return g.ToGrid().StringDots(filter)
+}
@@ -0,0 +1,60 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/gverger/aoc2024/utils"
+	"github.com/matryer/is"
+)
+
+func TestSparseGrid(t *testing.T) {
+	is := is.New(t)
+
+	g := utils.NewSparseGrid('.')
+
+	is.Equal(g.Width(), uint(0))
+	is.Equal(g.At(100, -100), '.') // Unset cells are the default
+
+	g.Set(-2, 3, '#')
+	g.Set(4, -1, '#')
+
+	is.Equal(g.Width(), uint(7))
+	is.Equal(g.Height(), uint(5))
+	is.Equal(g.MinX, -2)
+	is.Equal(g.MaxY, 3)
+	is.True(g.IsCoordValid(0, 0))
+	is.True(!g.IsCoordValid(5, 0))
+	is.Equal(g.At(-2, 3), '#')
+	is.Equal(g.Len(), 2)
+
+	cells := make([]utils.Cell[rune], 0)
+	for c := range g.AllCells() {
+		cells = append(cells, c)
+	}
+	is.Equal(cells, []utils.Cell[rune]{
+		{X: 4, Y: -1, Value: '#'},
+		{X: -2, Y: 3, Value: '#'},
+	})
+}
+
+func TestSparseGridConversion(t *testing.T) {
+	is := is.New(t)
+
+	g := utils.NewSparseGrid(0)
+	g.Set(-1, 5, 1)
+	g.Set(1, 7, 2)
+
+	dense := g.ToGrid()
+	is.Equal(dense.Width, uint(3))
+	is.Equal(dense.Height, uint(3))
+	is.Equal(dense.At(-1, 5), 1)
+	is.Equal(dense.At(0, 6), 0)
+	is.Equal(dense.At(1, 7), 2)
+
+	back := utils.SparseGridFrom(*dense, 0)
+	is.Equal(back.Len(), 9)
+	is.Equal(back.At(1, 7), 2)
+	is.Equal(back.MinX, -1)
+
+	is.Equal(g.Stringf(func(i int) string { return string(rune('0' + i)) }), "[3x3] grid:\n100\n000\n002")
+}